@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/planner"
+)
+
+// runExclusives prints a completion checklist comparing two or more versions,
+// e.g. `pokedex exclusives ruby sapphire emerald`.
+func runExclusives(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: pokedex exclusives <version> <version> [version...]")
+	}
+	versions := make([]data.GameVersion, 0, len(args))
+	for _, a := range args {
+		v, ok := data.ParseGameVersion(a)
+		if !ok {
+			return fmt.Errorf("unknown version %q", a)
+		}
+		versions = append(versions, v)
+	}
+	return planner.Build(data.AllPokemon, versions).WriteChecklist(os.Stdout)
+}
@@ -18,6 +18,12 @@ type apiNamedResource struct {
 	URL  string `json:"url"`
 }
 
+// apiName is one entry of a resource's localized "names" list.
+type apiName struct {
+	Name     string           `json:"name"`
+	Language apiNamedResource `json:"language"`
+}
+
 type apiPokemon struct {
 	ID     int    `json:"id"`
 	Name   string `json:"name"`
@@ -63,8 +69,12 @@ type apiMove struct {
 	Type        apiNamedResource `json:"type"`
 	PastValues  []struct {
 		Type         *apiNamedResource `json:"type"`
+		Power        *int              `json:"power"`
+		Accuracy     *int              `json:"accuracy"`
+		PP           *int              `json:"pp"`
 		VersionGroup apiNamedResource  `json:"version_group"`
 	} `json:"past_values"`
+	Names []apiName `json:"names"`
 }
 
 type apiAbility struct {
@@ -74,6 +84,7 @@ type apiAbility struct {
 		Language    apiNamedResource `json:"language"`
 		ShortEffect string           `json:"short_effect"`
 	} `json:"effect_entries"`
+	Names []apiName `json:"names"`
 }
 
 type apiEncounterEntry struct {
@@ -90,6 +101,41 @@ type apiEncounterEntry struct {
 	} `json:"version_details"`
 }
 
+type apiSpecies struct {
+	ID                 int               `json:"id"`
+	Name               string            `json:"name"`
+	EvolvesFromSpecies *apiNamedResource `json:"evolves_from_species"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	GrowthRate  apiNamedResource `json:"growth_rate"`
+	CaptureRate int              `json:"capture_rate"`
+	Names       []apiName        `json:"names"`
+}
+
+type apiChainLink struct {
+	Species          apiNamedResource `json:"species"`
+	EvolutionDetails []struct {
+		MinLevel *int             `json:"min_level"`
+		Trigger  apiNamedResource `json:"trigger"`
+	} `json:"evolution_details"`
+	EvolvesTo []apiChainLink `json:"evolves_to"`
+}
+
+type apiEvolutionChain struct {
+	ID    int          `json:"id"`
+	Chain apiChainLink `json:"chain"`
+}
+
+type apiPokedex struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	PokemonEntries []struct {
+		EntryNumber    int              `json:"entry_number"`
+		PokemonSpecies apiNamedResource `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
 // --- Enum parsing ---
 
 // ParseGeneration converts "generation-i" → 1, "generation-ii" → 2, "generation-iii" → 3.
@@ -284,6 +330,36 @@ func encounterMethodConstant(name string) string {
 	return "EncounterWalk"
 }
 
+// evolutionTriggerConstant converts an evolution trigger name to its constant name.
+func evolutionTriggerConstant(name string) string {
+	switch name {
+	case "level-up":
+		return "EvolveLevelUp"
+	case "trade":
+		return "EvolveTrade"
+	case "use-item":
+		return "EvolveItem"
+	}
+	return "EvolveOther"
+}
+
+// growthRateConstant converts a PokeAPI growth rate name to its constant name.
+func growthRateConstant(name string) string {
+	switch name {
+	case "slow-then-very-fast":
+		return "GrowthErratic"
+	case "fast-then-very-slow":
+		return "GrowthFluctuating"
+	case "medium-slow":
+		return "GrowthMediumSlow"
+	case "fast":
+		return "GrowthFast"
+	case "slow":
+		return "GrowthSlow"
+	}
+	return "GrowthMediumFast"
+}
+
 // idFromURL extracts the numeric ID from a PokeAPI URL like ".../ability/65/".
 func idFromURL(url string) (int, error) {
 	url = strings.TrimRight(url, "/")
@@ -307,6 +383,9 @@ type MoveData struct {
 	PP       uint8
 	// PastTypes: each entry is {UntilGen, TypeConst}
 	PastTypes []MovePastTypeData
+	// PastValues: power, accuracy and PP before later changes; 0 = unchanged
+	PastValues []MovePastValuesData
+	Names      [numLanguages]string
 }
 
 // MovePastTypeData records a move's past type for codegen.
@@ -315,11 +394,21 @@ type MovePastTypeData struct {
 	TypeConst string // e.g. "TypeNormal"
 }
 
+// MovePastValuesData records a move's power, accuracy and PP before a change,
+// in the order the changes happened; a zero field didn't change.
+type MovePastValuesData struct {
+	UntilGen byte
+	Power    uint8
+	Accuracy uint8
+	PP       uint8
+}
+
 // AbilityData is the parsed representation of an ability.
 type AbilityData struct {
 	ID        int
 	Name      string
 	ShortDesc string
+	Names     [numLanguages]string
 }
 
 // VersionedMoveEntry is a move learned by a specific method in a version.
@@ -348,6 +437,13 @@ type LocationData struct {
 	AreaName        string
 }
 
+// EvolutionData records how a pokemon evolves from its pre-evolution.
+type EvolutionData struct {
+	From     int    // 0 = base form (or pre-evolution outside Gen 1-3)
+	Trigger  string // e.g. "EvolveTrade"
+	MinLevel uint8
+}
+
 // PokemonData is the parsed representation of a pokemon.
 type PokemonData struct {
 	ID        int
@@ -365,9 +461,15 @@ type PokemonData struct {
 	Weight    uint16
 	Ability1  int // 0 = none
 	Ability2  int // 0 = none
+	HiddenAbility int // 0 = none
 	// VersionedMoves: grouped by game version constant
 	VersionedMoves map[string][]VersionedMoveEntry
 	Locations      []LocationData
+	Evolution      EvolutionData
+	DexNumbers     [3]int // Kanto, Johto, Hoenn; 0 = not listed
+	GrowthRate     string // e.g. "GrowthMediumSlow"
+	CaptureRate    uint8  // 0 = unknown
+	Names          [numLanguages]string
 }
 
 // typeConstant converts a byte type value to its Go constant name.
@@ -395,6 +497,42 @@ func readJSON(path string, v interface{}) error {
 	return json.NewDecoder(f).Decode(v)
 }
 
+// numLanguages is data.NumLanguages.
+const numLanguages = 8
+
+// languageSlots maps PokeAPI language names to their data.Language index.
+var languageSlots = map[string]int{
+	"en": 0, "ja-Hrkt": 1, "roomaji": 2, "fr": 3, "de": 4, "es": 5, "it": 6, "ko": 7,
+}
+
+// localNames collects the names in the languages data.LocalNames holds.
+// Moves and abilities have no romaji in PokeAPI, so it is derived from the kana.
+func localNames(names []apiName) [numLanguages]string {
+	var out [numLanguages]string
+	for _, n := range names {
+		if slot, ok := languageSlots[n.Language.Name]; ok {
+			out[slot] = n.Name
+		}
+	}
+	if out[2] == "" && out[1] != "" {
+		out[2] = kanaToRomaji(out[1])
+	}
+	return out
+}
+
+// localNamesLiteral renders names as a data.LocalNames literal, or "" when
+// there are none.
+func localNamesLiteral(names [numLanguages]string) string {
+	if names == [numLanguages]string{} {
+		return ""
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = strconv.Quote(n)
+	}
+	return "LocalNames{" + strings.Join(quoted, ", ") + "}"
+}
+
 // --- Build functions ---
 
 // BuildMove parses a move JSON file path and returns a MoveData.
@@ -427,7 +565,23 @@ func BuildMove(path string) (MoveData, error) {
 	// Build past types: the version_group in past_values marks when the change HAPPENED.
 	// UntilGen = prevGen(version_group) = the last generation where the old type applied.
 	var pastTypes []MovePastTypeData
+	var pastValues []MovePastValuesData
 	for _, pv := range m.PastValues {
+		if untilGen := prevGenForVersionGroup(pv.VersionGroup.Name); untilGen != 0 {
+			v := MovePastValuesData{UntilGen: untilGen}
+			if pv.Power != nil {
+				v.Power = uint8(*pv.Power)
+			}
+			if pv.Accuracy != nil {
+				v.Accuracy = uint8(*pv.Accuracy)
+			}
+			if pv.PP != nil {
+				v.PP = uint8(*pv.PP)
+			}
+			if v.Power != 0 || v.Accuracy != 0 || v.PP != 0 {
+				pastValues = append(pastValues, v)
+			}
+		}
 		if pv.Type == nil {
 			continue
 		}
@@ -453,7 +607,9 @@ func BuildMove(path string) (MoveData, error) {
 		Power:     power,
 		Accuracy:  accuracy,
 		PP:        pp,
-		PastTypes: pastTypes,
+		PastTypes:  pastTypes,
+		PastValues: pastValues,
+		Names:      localNames(m.Names),
 	}, nil
 }
 
@@ -474,6 +630,7 @@ func BuildAbility(path string) (AbilityData, error) {
 		ID:        a.ID,
 		Name:      a.Name,
 		ShortDesc: shortDesc,
+		Names:     localNames(a.Names),
 	}, nil
 }
 
@@ -510,6 +667,8 @@ func CollectMoves(dataDir string, pokemonIDs []int) (map[int]MoveData, error) {
 }
 
 // CollectAbilities reads all ability files referenced by Pokemon in dataDir and returns a map[id]AbilityData.
+// Hidden abilities are skipped: no Gen 1-3 game has them, so one that's only
+// ever hidden isn't generated at all.
 func CollectAbilities(dataDir string, pokemonIDs []int) (map[int]AbilityData, error) {
 	abilityIDs := make(map[int]bool)
 	for _, id := range pokemonIDs {
@@ -557,6 +716,76 @@ func learnMethodConstant(name string) string {
 	return "LearnLevelUp"
 }
 
+// findChainLink returns the link for speciesID within an evolution chain, or nil.
+func findChainLink(link *apiChainLink, speciesID int) *apiChainLink {
+	if id, err := idFromURL(link.Species.URL); err == nil && id == speciesID {
+		return link
+	}
+	for i := range link.EvolvesTo {
+		if found := findChainLink(&link.EvolvesTo[i], speciesID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// readSpecies reads a pokemon-species file; a missing file returns (nil, nil).
+func readSpecies(dataDir string, id int) (*apiSpecies, error) {
+	path := filepath.Join(dataDir, "pokemon-species", strconv.Itoa(id), "index.json")
+	var sp apiSpecies
+	if err := readJSON(path, &sp); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sp, nil
+}
+
+// BuildEvolution reads the species and evolution chain files for a pokemon.
+// A missing species file is treated as a base form with no evolution data.
+func BuildEvolution(dataDir string, id int) (EvolutionData, error) {
+	sp, err := readSpecies(dataDir, id)
+	if err != nil {
+		return EvolutionData{}, err
+	}
+	if sp == nil || sp.EvolvesFromSpecies == nil {
+		return EvolutionData{}, nil
+	}
+	from, err := idFromURL(sp.EvolvesFromSpecies.URL)
+	if err != nil {
+		return EvolutionData{}, err
+	}
+	if from > maxNationalID {
+		// e.g. Munchlax → Snorlax: the baby form was added in Gen 4.
+		return EvolutionData{}, nil
+	}
+	evo := EvolutionData{From: from, Trigger: "EvolveOther"}
+
+	chainID, err := idFromURL(sp.EvolutionChain.URL)
+	if err != nil {
+		return evo, nil
+	}
+	chainPath := filepath.Join(dataDir, "evolution-chain", strconv.Itoa(chainID), "index.json")
+	var chain apiEvolutionChain
+	if err := readJSON(chainPath, &chain); err != nil {
+		if os.IsNotExist(err) {
+			return evo, nil
+		}
+		return EvolutionData{}, err
+	}
+	link := findChainLink(&chain.Chain, id)
+	if link == nil || len(link.EvolutionDetails) == 0 {
+		return evo, nil
+	}
+	detail := link.EvolutionDetails[0]
+	evo.Trigger = evolutionTriggerConstant(detail.Trigger.Name)
+	if detail.MinLevel != nil {
+		evo.MinLevel = uint8(*detail.MinLevel)
+	}
+	return evo, nil
+}
+
 // BuildPokemon parses a single pokemon JSON and returns a PokemonData.
 func BuildPokemon(dataDir string, id int, abilities map[int]AbilityData) (PokemonData, error) {
 	path := filepath.Join(dataDir, "pokemon", strconv.Itoa(id), "index.json")
@@ -603,17 +832,16 @@ func BuildPokemon(dataDir string, id int, abilities map[int]AbilityData) (Pokemo
 		statsMap[s.Stat.Name] = uint8(s.BaseStat)
 	}
 
-	// Abilities (non-hidden only, slots 1 and 2)
-	var ab1, ab2 int
+	// Abilities: slots 1 and 2, and the hidden one
+	var ab1, ab2, hidden int
 	for _, a := range p.Abilities {
-		if a.IsHidden {
-			continue
-		}
 		aid, err := idFromURL(a.Ability.URL)
 		if err != nil {
 			continue
 		}
-		if a.Slot == 1 {
+		if a.IsHidden {
+			hidden = aid
+		} else if a.Slot == 1 {
 			ab1 = aid
 		} else if a.Slot == 2 {
 			ab2 = aid
@@ -685,6 +913,24 @@ func BuildPokemon(dataDir string, id int, abilities map[int]AbilityData) (Pokemo
 		}
 	}
 
+	evo, err := BuildEvolution(dataDir, id)
+	if err != nil {
+		return PokemonData{}, fmt.Errorf("evolution: %w", err)
+	}
+
+	growthRate := "GrowthMediumFast"
+	var captureRate uint8
+	var names [numLanguages]string
+	sp, err := readSpecies(dataDir, id)
+	if err != nil {
+		return PokemonData{}, fmt.Errorf("species: %w", err)
+	}
+	if sp != nil {
+		growthRate = growthRateConstant(sp.GrowthRate.Name)
+		captureRate = uint8(sp.CaptureRate)
+		names = localNames(sp.Names)
+	}
+
 	return PokemonData{
 		ID:             p.ID,
 		Name:           p.Name,
@@ -701,31 +947,102 @@ func BuildPokemon(dataDir string, id int, abilities map[int]AbilityData) (Pokemo
 		Weight:         uint16(p.Weight),
 		Ability1:       ab1,
 		Ability2:       ab2,
+		HiddenAbility:  hidden,
 		VersionedMoves: versionedMoves,
 		Locations:      locations,
+		Evolution:      evo,
+		GrowthRate:     growthRate,
+		CaptureRate:    captureRate,
+		Names:          names,
 	}, nil
 }
 
+// regionalDexIDs are the PokeAPI pokedex IDs for Kanto, original Johto and Hoenn,
+// in the order of data.Pokemon.DexNumbers.
+var regionalDexIDs = [3]int{2, 3, 4}
+
+// CollectDexNumbers reads the regional pokedex files and returns species ID → regional numbers.
+// Missing pokedex files are skipped.
+func CollectDexNumbers(dataDir string) (map[int][3]int, error) {
+	numbers := make(map[int][3]int)
+	for slot, dexID := range regionalDexIDs {
+		path := filepath.Join(dataDir, "pokedex", strconv.Itoa(dexID), "index.json")
+		var dex apiPokedex
+		if err := readJSON(path, &dex); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading pokedex %d: %w", dexID, err)
+		}
+		for _, e := range dex.PokemonEntries {
+			id, err := idFromURL(e.PokemonSpecies.URL)
+			if err != nil {
+				return nil, err
+			}
+			n := numbers[id]
+			n[slot] = e.EntryNumber
+			numbers[id] = n
+		}
+	}
+	return numbers, nil
+}
+
+// CollectAliases returns the English display name of each pokemon whose name
+// differs from its dex name, e.g. "Mr. Mime" → "mr-mime" and "Deoxys" →
+// "deoxys-normal". Pokemon without a species file are skipped.
+func CollectAliases(dataDir string, pokemon []PokemonData) (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, pk := range pokemon {
+		sp, err := readSpecies(dataDir, pk.ID)
+		if err != nil {
+			return nil, fmt.Errorf("reading species %d: %w", pk.ID, err)
+		}
+		if sp == nil {
+			continue
+		}
+		for _, n := range sp.Names {
+			if n.Language.Name == "en" && strings.ToLower(n.Name) != pk.Name {
+				aliases[n.Name] = pk.Name
+			}
+		}
+	}
+	return aliases, nil
+}
+
 // --- Code generation templates ---
 
-var abilitiesTemplate = template.Must(template.New("abilities").Parse(`// Code generated by cmd/gen/main.go. DO NOT EDIT.
+var abilitiesTemplate = template.Must(template.New("abilities").Funcs(template.FuncMap{
+	"localNames": localNamesLiteral,
+}).Parse(`// Code generated by cmd/gen/main.go. DO NOT EDIT.
 package data
 
 func init() {
 	AllAbilities = make([]*Ability, {{.Size}})
 {{- range .Abilities}}
-	AllAbilities[{{.ID}}] = &Ability{ID: {{.ID}}, Name: {{printf "%q" .Name}}, ShortDesc: {{printf "%q" .ShortDesc}}}
+	AllAbilities[{{.ID}}] = &Ability{ID: {{.ID}}, Name: {{printf "%q" .Name}}, ShortDesc: {{printf "%q" .ShortDesc}}{{with localNames .Names}}, Names: {{.}}{{end}}}
 {{- end}}
 }
 `))
 
+var aliasesTemplate = template.Must(template.New("aliases").Parse(`// Code generated by cmd/gen/main.go. DO NOT EDIT.
+package data
+
+func init() {
+	Aliases = map[string]string{
+{{- range .}}
+		{{printf "%q" .Alias}}: {{printf "%q" .Name}},
+{{- end}}
+	}
+}
+`))
+
 var movesTemplate = template.Must(template.New("moves").Parse(`// Code generated by cmd/gen/main.go. DO NOT EDIT.
 package data
 
 func init() {
 	AllMoves = make([]*Move, {{.Size}})
 {{- range .Moves}}
-	AllMoves[{{.ID}}] = &Move{ID: {{.ID}}, Name: {{printf "%q" .Name}}, Type: {{.TypeConst}}, Category: {{.CategoryConst}}, Power: {{.Power}}, Accuracy: {{.Accuracy}}, PP: {{.PP}}{{if .PastTypes}}, PastTypes: []MoveTypePast{ {{- range .PastTypes}}{UntilGen: {{.UntilGen}}, Type: {{.TypeConst}}}, {{end}}}{{end}}}
+	AllMoves[{{.ID}}] = &Move{ID: {{.ID}}, Name: {{printf "%q" .Name}}, Type: {{.TypeConst}}, Category: {{.CategoryConst}}, Power: {{.Power}}, Accuracy: {{.Accuracy}}, PP: {{.PP}}{{if .PastTypes}}, PastTypes: []MoveTypePast{ {{- range .PastTypes}}{UntilGen: {{.UntilGen}}, Type: {{.TypeConst}}}, {{end}}}{{end}}{{if .PastValues}}, PastValues: []MoveValuesPast{ {{- range .PastValues}}{UntilGen: {{.UntilGen}}, Power: {{.Power}}, Accuracy: {{.Accuracy}}, PP: {{.PP}}}, {{end}}}{{end}}{{with .NamesLit}}, Names: {{.}}{{end}}}
 {{- end}}
 }
 `))
@@ -763,6 +1080,9 @@ func init() {
 			Height:    {{.Height}},
 			Weight:    {{.Weight}},
 			Abilities: [2]AbilityID{ {{.Ability1}}, {{.Ability2}} },
+			{{- if .HiddenAbility}}
+			HiddenAbility: {{.HiddenAbility}},
+			{{- end}}
 			{{- if .VersionedMoves}}
 			Moves: []VersionedLearnset{
 				{{- range (sortedVersions .VersionedMoves)}}
@@ -830,6 +1150,9 @@ func categoryConstant(c byte) string {
 
 // --- Run: full codegen pipeline ---
 
+// maxNationalID is the last national dex number in scope (Deoxys, Gen 3).
+const maxNationalID = 386
+
 // Config holds codegen parameters.
 type Config struct {
 	DataDir    string // path to api/v2/
@@ -841,7 +1164,7 @@ type Config struct {
 func Run(cfg Config) error {
 	ids := cfg.PokemonIDs
 	if ids == nil {
-		ids = make([]int, 386)
+		ids = make([]int, maxNationalID)
 		for i := range ids {
 			ids[i] = i + 1
 		}
@@ -869,6 +1192,12 @@ func Run(cfg Config) error {
 		return fmt.Errorf("collecting abilities: %w", err)
 	}
 
+	// Collect regional dex numbers
+	dexNumbers, err := CollectDexNumbers(cfg.DataDir)
+	if err != nil {
+		return fmt.Errorf("collecting dex numbers: %w", err)
+	}
+
 	// Collect pokemon
 	var allPokemon []PokemonData
 	for _, id := range ids {
@@ -876,13 +1205,25 @@ func Run(cfg Config) error {
 		if err != nil {
 			return fmt.Errorf("building pokemon %d: %w", id, err)
 		}
+		pk.DexNumbers = dexNumbers[id]
 		allPokemon = append(allPokemon, pk)
 	}
 
+	// Collect display-name aliases
+	aliases, err := CollectAliases(cfg.DataDir, allPokemon)
+	if err != nil {
+		return fmt.Errorf("collecting aliases: %w", err)
+	}
+
 	if err := os.MkdirAll(cfg.OutDir, 0755); err != nil {
 		return err
 	}
 
+	// Emit aliases_gen.go
+	if err := emitAliases(cfg.OutDir, aliases); err != nil {
+		return err
+	}
+
 	// Emit abilities_gen.go
 	if err := emitAbilities(cfg.OutDir, abilities); err != nil {
 		return err
@@ -933,6 +1274,25 @@ func emitAbilities(outDir string, abilities map[int]AbilityData) error {
 	return abilitiesTemplate.Execute(f, tplData{Size: maxID + 10, Abilities: sorted})
 }
 
+func emitAliases(outDir string, aliases map[string]string) error {
+	type aliasEntry struct {
+		Alias string
+		Name  string
+	}
+	entries := make([]aliasEntry, 0, len(aliases))
+	for alias, name := range aliases {
+		entries = append(entries, aliasEntry{alias, name})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Alias < entries[j].Alias })
+
+	f, err := os.Create(filepath.Join(outDir, "aliases_gen.go"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return aliasesTemplate.Execute(f, entries)
+}
+
 func emitMoves(outDir string, moves map[int]MoveData) error {
 	ids := make([]int, 0, len(moves))
 	for id := range moves {
@@ -959,6 +1319,8 @@ func emitMoves(outDir string, moves map[int]MoveData) error {
 			UntilGen  byte
 			TypeConst string
 		}
+		PastValues    []MovePastValuesData
+		NamesLit      string
 	}
 
 	entries := make([]moveTpl, 0, len(ids))
@@ -983,6 +1345,8 @@ func emitMoves(outDir string, moves map[int]MoveData) error {
 			Accuracy:      m.Accuracy,
 			PP:            m.PP,
 			PastTypes:     pastTypes,
+			PastValues:    m.PastValues,
+			NamesLit:      localNamesLiteral(m.Names),
 		})
 	}
 
@@ -1016,8 +1380,14 @@ type pokemonTplEntry struct {
 	Weight         uint16
 	Ability1       int
 	Ability2       int
+	HiddenAbility  int
 	VersionedMoves map[string][]VersionedMoveEntry
 	Locations      []LocationData
+	Evolution      EvolutionData
+	DexNumbers     [3]int
+	GrowthRate     string
+	CaptureRate    uint8
+	Names          [numLanguages]string
 	PokemonIdx     int
 }
 
@@ -1041,8 +1411,14 @@ func emitPokemon(outDir string, pokemon []PokemonData) error {
 			Weight:         p.Weight,
 			Ability1:       p.Ability1,
 			Ability2:       p.Ability2,
+			HiddenAbility:  p.HiddenAbility,
 			VersionedMoves: p.VersionedMoves,
 			Locations:      p.Locations,
+			Evolution:      p.Evolution,
+			DexNumbers:     p.DexNumbers,
+			GrowthRate:     p.GrowthRate,
+			CaptureRate:    p.CaptureRate,
+			Names:          p.Names,
 			PokemonIdx:     i,
 		}
 	}
@@ -1085,6 +1461,9 @@ func emitPokemonDirect(outDir string, entries []pokemonTplEntry) error {
 		fmt.Fprintf(f, "\t\t\tHeight:    %d,\n", p.Height)
 		fmt.Fprintf(f, "\t\t\tWeight:    %d,\n", p.Weight)
 		fmt.Fprintf(f, "\t\t\tAbilities: [2]AbilityID{%d, %d},\n", p.Ability1, p.Ability2)
+		if p.HiddenAbility != 0 {
+			fmt.Fprintf(f, "\t\t\tHiddenAbility: %d,\n", p.HiddenAbility)
+		}
 
 		if len(p.VersionedMoves) > 0 {
 			// Sort versions for deterministic output
@@ -1116,6 +1495,28 @@ func emitPokemonDirect(outDir string, entries []pokemonTplEntry) error {
 			fmt.Fprintf(f, "\t\t\t},\n")
 		}
 
+		if p.Evolution.From > 0 {
+			fmt.Fprintf(f, "\t\t\tEvolvesFrom:      %d,\n", p.Evolution.From)
+			fmt.Fprintf(f, "\t\t\tEvolutionTrigger: %s,\n", p.Evolution.Trigger)
+			fmt.Fprintf(f, "\t\t\tEvolutionLevel:   %d,\n", p.Evolution.MinLevel)
+		}
+
+		if p.DexNumbers != [3]int{} {
+			fmt.Fprintf(f, "\t\t\tDexNumbers: [3]uint16{%d, %d, %d},\n", p.DexNumbers[0], p.DexNumbers[1], p.DexNumbers[2])
+		}
+
+		if p.GrowthRate != "" && p.GrowthRate != "GrowthMediumFast" {
+			fmt.Fprintf(f, "\t\t\tGrowthRate: %s,\n", p.GrowthRate)
+		}
+
+		if p.CaptureRate != 0 {
+			fmt.Fprintf(f, "\t\t\tCaptureRate: %d,\n", p.CaptureRate)
+		}
+
+		if lit := localNamesLiteral(p.Names); lit != "" {
+			fmt.Fprintf(f, "\t\t\tNames: %s,\n", lit)
+		}
+
 		fmt.Fprintf(f, "\t\t},\n")
 	}
 
@@ -5,7 +5,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"unicode/utf8"
 )
 
 var testdataDir = filepath.Join("testdata")
@@ -113,6 +115,18 @@ func TestBuildMove_Stable(t *testing.T) {
 	}
 }
 
+func TestBuildMove_PastValues(t *testing.T) {
+	m, err := BuildMove(filepath.Join(testdataDir, "move", "33", "index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both changes came after Gen 3, so both are recorded as until Gen 3.
+	want := []MovePastValuesData{{UntilGen: 3, Power: 35, Accuracy: 95}, {UntilGen: 3, Power: 50}}
+	if !reflect.DeepEqual(m.PastValues, want) {
+		t.Errorf("PastValues = %+v, want %+v", m.PastValues, want)
+	}
+}
+
 func TestBuildMove_WithPastType(t *testing.T) {
 	m, err := BuildMove(filepath.Join(testdataDir, "move", "44", "index.json"))
 	if err != nil {
@@ -157,13 +171,32 @@ func TestBuildPokemon_Abilities(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	// Bulbasaur: slot 1 = overgrow (65), hidden = chlorophyll (34, skipped)
+	// Bulbasaur: slot 1 = overgrow (65), hidden = chlorophyll (34)
 	if pk.Ability1 != 65 {
 		t.Errorf("Ability1 = %d, want 65 (overgrow)", pk.Ability1)
 	}
 	if pk.Ability2 != 0 {
 		t.Errorf("Ability2 = %d, want 0 (no second non-hidden ability)", pk.Ability2)
 	}
+	if pk.HiddenAbility != 34 {
+		t.Errorf("HiddenAbility = %d, want 34 (chlorophyll)", pk.HiddenAbility)
+	}
+}
+
+func TestCollectAbilities_SkipsHidden(t *testing.T) {
+	abilities, err := CollectAbilities(testdataDir, []int{1, 6, 81})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []int{65, 66, 42, 5} {
+		if _, ok := abilities[id]; !ok {
+			t.Errorf("ability %d missing", id)
+		}
+	}
+	// Only Bulbasaur has chlorophyll here, and only as its hidden ability.
+	if _, ok := abilities[34]; ok {
+		t.Error("collected chlorophyll (34), a hidden-only ability")
+	}
 }
 
 func TestBuildAbility_English(t *testing.T) {
@@ -186,6 +219,163 @@ func TestBuildAbility_English(t *testing.T) {
 	}
 }
 
+func TestBuildEvolution_LevelUp(t *testing.T) {
+	evo, err := BuildEvolution(testdataDir, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evo.From != 5 {
+		t.Errorf("From = %d, want 5 (charmeleon)", evo.From)
+	}
+	if evo.Trigger != "EvolveLevelUp" {
+		t.Errorf("Trigger = %q, want \"EvolveLevelUp\"", evo.Trigger)
+	}
+	if evo.MinLevel != 36 {
+		t.Errorf("MinLevel = %d, want 36", evo.MinLevel)
+	}
+}
+
+func TestBuildEvolution_Trade(t *testing.T) {
+	evo, err := BuildEvolution(testdataDir, 76)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evo.From != 75 {
+		t.Errorf("From = %d, want 75 (graveler)", evo.From)
+	}
+	if evo.Trigger != "EvolveTrade" {
+		t.Errorf("Trigger = %q, want \"EvolveTrade\"", evo.Trigger)
+	}
+}
+
+func TestBuildEvolution_BaseForm(t *testing.T) {
+	// Bulbasaur has a species file but no pre-evolution; Magnemite has no species file.
+	for _, id := range []int{1, 81} {
+		evo, err := BuildEvolution(testdataDir, id)
+		if err != nil {
+			t.Fatalf("BuildEvolution(%d): %v", id, err)
+		}
+		if evo.From != 0 {
+			t.Errorf("BuildEvolution(%d).From = %d, want 0", id, evo.From)
+		}
+	}
+}
+
+func TestBuildPokemon_GrowthRate(t *testing.T) {
+	pk, err := BuildPokemon(testdataDir, 6, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.GrowthRate != "GrowthMediumSlow" {
+		t.Errorf("GrowthRate = %q, want \"GrowthMediumSlow\"", pk.GrowthRate)
+	}
+	if pk.CaptureRate != 45 {
+		t.Errorf("CaptureRate = %d, want 45", pk.CaptureRate)
+	}
+	// Magnemite has no species fixture and falls back to Medium Fast.
+	pk, err = BuildPokemon(testdataDir, 81, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.GrowthRate != "GrowthMediumFast" {
+		t.Errorf("GrowthRate = %q, want \"GrowthMediumFast\"", pk.GrowthRate)
+	}
+}
+
+func TestCollectDexNumbers(t *testing.T) {
+	numbers, err := CollectDexNumbers(testdataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Magnemite: Kanto #81, not in the (absent) Johto fixture, Hoenn #82.
+	if got, want := numbers[81], [3]int{81, 0, 82}; got != want {
+		t.Errorf("numbers[81] = %v, want %v", got, want)
+	}
+	if got, want := numbers[6], [3]int{6, 0, 0}; got != want {
+		t.Errorf("numbers[6] = %v, want %v", got, want)
+	}
+}
+
+func TestBuildMove_LocalNames(t *testing.T) {
+	m, err := BuildMove(filepath.Join(testdataDir, "move", "33", "index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [numLanguages]string{"Tackle", "たいあたり", "Taiatari", "Charge", "Tackle", "Placaje", "Azione", "몸통박치기"}
+	if m.Names != want {
+		t.Errorf("Names = %q, want %q", m.Names, want)
+	}
+	if got := localNamesLiteral(m.Names); got != `LocalNames{"Tackle", "たいあたり", "Taiatari", "Charge", "Tackle", "Placaje", "Azione", "몸통박치기"}` {
+		t.Errorf("localNamesLiteral = %s", got)
+	}
+	if got := localNamesLiteral([numLanguages]string{}); got != "" {
+		t.Errorf("localNamesLiteral(empty) = %q, want \"\"", got)
+	}
+}
+
+func TestBuildPokemon_LocalNames(t *testing.T) {
+	pk, err := BuildPokemon(testdataDir, 6, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// PokeAPI's romaji is the trademark name, not a transliteration of the kana.
+	if pk.Names[1] != "リザードン" || pk.Names[2] != "Lizardon" || pk.Names[4] != "Glurak" {
+		t.Errorf("Names = %q", pk.Names)
+	}
+}
+
+func TestKanaToRomaji(t *testing.T) {
+	cases := map[string]string{
+		"たいあたり":   "Taiatari",
+		"しんりょく":   "Shinryoku",
+		"ヒトカゲ":    "Hitokage",
+		"リザードン":   "Rizaadon",
+		"ピカチュウ":   "Pikachuu",
+		"ハイドロポンプ": "Haidoroponpu",
+		"マッハパンチ":  "Mahhapanchi",
+		"ファイヤー":   "Faiyaa",
+		"ジャローダ":   "Jarooda",
+		// Runes that aren't kana next to the marks that modify a neighbour.
+		"ポリゴン２": "Porigon２",
+		"ニドラン♀": "Nidoran♀",
+		"ーア":    "ーa",
+		"♀ャ":    "♀ya",
+		"ッ！":    "！",
+		"技ー":    "技ー",
+	}
+	for in, want := range cases {
+		got := kanaToRomaji(in)
+		if got != want {
+			t.Errorf("kanaToRomaji(%q) = %q, want %q", in, got, want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("kanaToRomaji(%q) = %q, not valid UTF-8", in, got)
+		}
+	}
+}
+
+func TestCollectAliases(t *testing.T) {
+	pokemon := []PokemonData{
+		{ID: 6, Name: "charizard"},
+		{ID: 29, Name: "nidoran-f"},
+		{ID: 81, Name: "magnemite"}, // no species fixture
+		{ID: 122, Name: "mr-mime"},
+	}
+	aliases, err := CollectAliases(testdataDir, pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"Nidoran♀": "nidoran-f", "Mr. Mime": "mr-mime"}
+	if len(aliases) != len(want) {
+		t.Errorf("aliases = %v, want %v", aliases, want)
+	}
+	for alias, name := range want {
+		if aliases[alias] != name {
+			t.Errorf("aliases[%q] = %q, want %q", alias, aliases[alias], name)
+		}
+	}
+}
+
 func TestCodegen_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -207,7 +397,7 @@ func TestCodegen_Integration(t *testing.T) {
 	}
 
 	// Verify generated files exist
-	for _, name := range []string{"abilities_gen.go", "moves_gen.go", "pokemon_gen.go"} {
+	for _, name := range []string{"abilities_gen.go", "aliases_gen.go", "moves_gen.go", "pokemon_gen.go"} {
 		path := filepath.Join(outDir, name)
 		if _, err := os.Stat(path); err != nil {
 			t.Errorf("expected file %s to exist: %v", name, err)
@@ -240,7 +430,7 @@ func buildTestProgram(t *testing.T, genDir string) {
 
 	// We only check that the files we generated are syntactically valid Go
 	// by running gofmt -e on them.
-	for _, name := range []string{"abilities_gen.go", "moves_gen.go", "pokemon_gen.go"} {
+	for _, name := range []string{"abilities_gen.go", "aliases_gen.go", "moves_gen.go", "pokemon_gen.go"} {
 		path := filepath.Join(genDir, name)
 		out, err := exec.Command("gofmt", "-e", path).CombinedOutput()
 		if err != nil {
@@ -0,0 +1,106 @@
+package gen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// kanaSyllables romanizes single katakana in Hepburn; hiragana is converted
+// to katakana first.
+var kanaSyllables = map[rune]string{
+	'ア': "a", 'イ': "i", 'ウ': "u", 'エ': "e", 'オ': "o",
+	'カ': "ka", 'キ': "ki", 'ク': "ku", 'ケ': "ke", 'コ': "ko",
+	'ガ': "ga", 'ギ': "gi", 'グ': "gu", 'ゲ': "ge", 'ゴ': "go",
+	'サ': "sa", 'シ': "shi", 'ス': "su", 'セ': "se", 'ソ': "so",
+	'ザ': "za", 'ジ': "ji", 'ズ': "zu", 'ゼ': "ze", 'ゾ': "zo",
+	'タ': "ta", 'チ': "chi", 'ツ': "tsu", 'テ': "te", 'ト': "to",
+	'ダ': "da", 'ヂ': "ji", 'ヅ': "zu", 'デ': "de", 'ド': "do",
+	'ナ': "na", 'ニ': "ni", 'ヌ': "nu", 'ネ': "ne", 'ノ': "no",
+	'ハ': "ha", 'ヒ': "hi", 'フ': "fu", 'ヘ': "he", 'ホ': "ho",
+	'バ': "ba", 'ビ': "bi", 'ブ': "bu", 'ベ': "be", 'ボ': "bo",
+	'パ': "pa", 'ピ': "pi", 'プ': "pu", 'ペ': "pe", 'ポ': "po",
+	'マ': "ma", 'ミ': "mi", 'ム': "mu", 'メ': "me", 'モ': "mo",
+	'ヤ': "ya", 'ユ': "yu", 'ヨ': "yo",
+	'ラ': "ra", 'リ': "ri", 'ル': "ru", 'レ': "re", 'ロ': "ro",
+	'ワ': "wa", 'ヲ': "o", 'ン': "n", 'ヴ': "vu",
+}
+
+// smallKana modify the syllable before them: ャュョ form yōon (キャ kya,
+// シャ sha) and ァィゥェォ replace its vowel (ファ fa).
+var smallKana = map[rune]string{
+	'ャ': "a", 'ュ': "u", 'ョ': "o",
+	'ァ': "a", 'ィ': "i", 'ゥ': "u", 'ェ': "e", 'ォ': "o",
+}
+
+// kanaToRomaji romanizes a kana name, for names PokeAPI has no romaji for.
+// Long vowels (ー) repeat the previous vowel rather than using macrons, and
+// runes that aren't kana are copied through.
+func kanaToRomaji(s string) string {
+	var out [][]rune // one romanized syllable, or copied rune, per entry
+	sokuon := false
+	// lastVowel is the vowel ending the previous syllable, or 0 when there
+	// is none to lengthen or modify.
+	lastVowel := func() rune {
+		if n := len(out); n > 0 {
+			if r := out[n-1][len(out[n-1])-1]; strings.ContainsRune("aeiou", r) {
+				return r
+			}
+		}
+		return 0
+	}
+	for _, r := range s {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			r += 'ァ' - 'ぁ'
+		}
+		if r == 'ッ' {
+			sokuon = true
+			continue
+		}
+		if r == 'ー' && lastVowel() != 0 {
+			out[len(out)-1] = append(out[len(out)-1], lastVowel())
+			continue
+		}
+		yoon := r == 'ャ' || r == 'ュ' || r == 'ョ'
+		if v, ok := smallKana[r]; ok && lastVowel() != 0 {
+			prev := out[len(out)-1]
+			stem := string(prev[:len(prev)-1])
+			switch {
+			case yoon && (stem == "sh" || stem == "ch" || stem == "j"):
+				out[len(out)-1] = []rune(stem + v)
+			case yoon:
+				out[len(out)-1] = []rune(stem + "y" + v)
+			default:
+				out[len(out)-1] = []rune(stem + v)
+			}
+			continue
+		}
+		var syl []rune
+		if v, ok := kanaSyllables[r]; ok {
+			syl = []rune(v)
+		} else if v, ok := smallKana[r]; ok && yoon {
+			syl = []rune("y" + v)
+		} else if ok {
+			syl = []rune(v)
+		} else {
+			syl = []rune{r}
+		}
+		if sokuon && syl[0] >= 'a' && syl[0] <= 'z' {
+			if syl[0] == 'c' {
+				syl = append([]rune{'t'}, syl...)
+			} else {
+				syl = append([]rune{syl[0]}, syl...)
+			}
+		}
+		sokuon = false
+		out = append(out, syl)
+	}
+	var name []rune
+	for _, syl := range out {
+		name = append(name, syl...)
+	}
+	if len(name) == 0 {
+		return ""
+	}
+	name[0] = unicode.ToUpper(name[0])
+	return string(name)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+	"github.com/davidlawson7/pokedex/internal/tui"
+)
+
+const inspectUsage = "usage: pokedex inspect [-tui] <file.pk1|.pk2|.pk3|.ek3>"
+
+// runInspect prints an individual Pokémon from a single-Pokémon file, or opens
+// it on the detail screen with -tui.
+func runInspect(args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	openTUI := fs.Bool("tui", false, "open the Pokémon in the detail screen")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf(inspectUsage)
+	}
+	p, err := save.ReadPokemon(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", fs.Arg(0), err)
+	}
+	if *openTUI {
+		return tui.Run(tui.Options{Individual: p})
+	}
+	return printPokemon(os.Stdout, p)
+}
+
+func printPokemon(w io.Writer, p *save.Pokemon) error {
+	name := fmt.Sprintf("#%03d", p.Species)
+	if sp := p.Data(); sp != nil {
+		name += " " + sp.Name
+	}
+	fmt.Fprintf(w, "%s %q  Gen %d  Lv%d", name, p.Nickname, p.Gen, p.Level)
+	if p.IsEgg {
+		fmt.Fprint(w, "  (egg)")
+	}
+	if p.Gen >= 2 && p.Shiny() {
+		fmt.Fprint(w, "  shiny")
+	}
+	fmt.Fprintln(w)
+	if p.Gen >= 3 {
+		fmt.Fprintf(w, "OT:      %s (%05d / %05d)\n", p.OTName, p.OTID, p.SecretID)
+		fmt.Fprintf(w, "Nature:  %s\n", p.Nature())
+		fmt.Fprintf(w, "Ability: slot %d\n", p.AbilitySlot+1)
+	} else {
+		fmt.Fprintf(w, "OT:      %s (%05d)\n", p.OTName, p.OTID)
+	}
+
+	var moves []string
+	for _, id := range p.Moves {
+		if id == 0 {
+			continue
+		}
+		if mv := data.MoveByID(id); mv != nil {
+			moves = append(moves, mv.Name)
+		} else {
+			moves = append(moves, fmt.Sprintf("move %d", id))
+		}
+	}
+	if len(moves) == 0 {
+		moves = []string{"—"}
+	}
+	fmt.Fprintf(w, "Moves:   %s\n", strings.Join(moves, ", "))
+
+	ivLabel, evLabel := "IV", "EV"
+	if p.Gen < 3 {
+		ivLabel, evLabel = "DV", "StatExp"
+	}
+	actual, ok := p.ActualStats()
+	fmt.Fprintf(w, "\n%-6s %3s %7s", "", ivLabel, evLabel)
+	if ok {
+		fmt.Fprintf(w, " %6s", "Actual")
+	}
+	fmt.Fprintln(w)
+	rows := []struct {
+		label          string
+		iv, ev, actual uint16
+	}{
+		{"HP", p.IVs.HP, p.EVs.HP, actual.HP},
+		{"Atk", p.IVs.Attack, p.EVs.Attack, actual.Attack},
+		{"Def", p.IVs.Defense, p.EVs.Defense, actual.Defense},
+		{"SpAtk", p.IVs.SpecialAttack, p.EVs.SpecialAttack, actual.SpecialAttack},
+		{"SpDef", p.IVs.SpecialDefense, p.EVs.SpecialDefense, actual.SpecialDefense},
+		{"Speed", p.IVs.Speed, p.EVs.Speed, actual.Speed},
+	}
+	for _, r := range rows {
+		fmt.Fprintf(w, "%-6s %3d %7d", r.label, r.iv, r.ev)
+		if ok {
+			fmt.Fprintf(w, " %6d", r.actual)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
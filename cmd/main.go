@@ -1,15 +1,57 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/davidlawson7/pokedex/internal/save"
 	"github.com/davidlawson7/pokedex/internal/tui"
 )
 
 func main() {
-	if err := tui.Run(); err != nil {
+	var err error
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		switch os.Args[1] {
+		case "exclusives":
+			err = runExclusives(os.Args[2:])
+		case "tracker":
+			err = runTracker(os.Args[2:])
+		case "inspect":
+			err = runInspect(os.Args[2:])
+		case "showdown":
+			err = runShowdown(os.Args[2:])
+		default:
+			err = fmt.Errorf("unknown command %q", os.Args[1])
+		}
+	} else {
+		err = runTUI(os.Args[1:])
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// runTUI starts the interactive browser, optionally importing a save file.
+func runTUI(args []string) error {
+	fs := flag.NewFlagSet("pokedex", flag.ContinueOnError)
+	savePath := fs.String("save", "", "save file to import into the tracker (read-only)")
+	lang := fs.String("lang", "", "display language for names: en, ja, romaji, fr, de, es, it or ko")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := tui.Options{Language: *lang}
+	if *savePath != "" {
+		s, err := save.Read(*savePath)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", *savePath, err)
+		}
+		opts.Save = s
+		opts.SaveName = strings.TrimSuffix(filepath.Base(*savePath), filepath.Ext(*savePath))
+	}
+	return tui.Run(opts)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+	"github.com/davidlawson7/pokedex/internal/showdown"
+)
+
+const showdownUsage = "usage: pokedex showdown <check [-version v] <paste|->|export <save or .pk file>>"
+
+// runShowdown validates Showdown pastes and exports saved Pokémon as pastes.
+func runShowdown(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(showdownUsage)
+	}
+	switch args[0] {
+	case "check":
+		return checkPaste(args[1:])
+	case "export":
+		if len(args) != 2 {
+			return fmt.Errorf(showdownUsage)
+		}
+		return exportPaste(args[1])
+	}
+	return fmt.Errorf(showdownUsage)
+}
+
+// checkPaste reports problems in a paste as file:line: message and prints the
+// normalised team.
+func checkPaste(args []string) error {
+	fs := flag.NewFlagSet("showdown check", flag.ContinueOnError)
+	versionName := fs.String("version", "emerald", "game version whose learnsets moves are checked against")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf(showdownUsage)
+	}
+	version, ok := data.ParseGameVersion(*versionName)
+	if !ok {
+		return fmt.Errorf("unknown version %q", *versionName)
+	}
+
+	name := fs.Arg(0)
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	sets, problems, err := showdown.Parse(r, version)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "%s:%d: %s\n", name, p.Line, p.Msg)
+	}
+	if err := showdown.Write(os.Stdout, sets); err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) found", len(problems))
+	}
+	return nil
+}
+
+// exportPaste prints a save's party, or a single .pk file's Pokémon, as a paste.
+func exportPaste(path string) error {
+	var mons []save.Pokemon
+	s, err := save.Read(path)
+	switch {
+	case err == nil:
+		mons = s.Party
+	case errors.Is(err, save.ErrUnknownFormat):
+		p, err := save.ReadPokemon(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		mons = []save.Pokemon{*p}
+	default:
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	sets := make([]showdown.Set, len(mons))
+	for i := range mons {
+		sets[i] = showdown.FromSave(&mons[i])
+	}
+	return showdown.Write(os.Stdout, sets)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/tracker"
+)
+
+const trackerUsage = "usage: pokedex tracker <stats|export [file]|import <file>|use <profile>>"
+
+// runTracker manages the seen/caught tracker outside the TUI.
+func runTracker(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(trackerUsage)
+	}
+	path, err := tracker.DefaultPath()
+	if err != nil {
+		return err
+	}
+	t, err := tracker.Load(path)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "stats":
+		return printTrackerStats(os.Stdout, t)
+
+	case "export":
+		if len(args) < 2 {
+			return t.Export(os.Stdout)
+		}
+		f, err := os.Create(args[1])
+		if err != nil {
+			return err
+		}
+		if err := t.Export(f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+
+	case "import":
+		if len(args) < 2 {
+			return fmt.Errorf(trackerUsage)
+		}
+		f, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := t.Import(f); err != nil {
+			return fmt.Errorf("importing %s: %w", args[1], err)
+		}
+		return t.Save()
+
+	case "use":
+		if len(args) < 2 {
+			return fmt.Errorf(trackerUsage)
+		}
+		t.SetActive(args[1])
+		return t.Save()
+	}
+	return fmt.Errorf(trackerUsage)
+}
+
+func printTrackerStats(w io.Writer, t *tracker.Tracker) error {
+	fmt.Fprintf(w, "Profile: %s\n", t.Active())
+	gens := t.ByGeneration(data.AllPokemon)
+	for gen := 1; gen <= 3; gen++ {
+		g := gens[gen]
+		fmt.Fprintf(w, "  Gen %d     caught %3d  seen %3d  of %3d\n", gen, g.Caught, g.Seen, g.Total)
+	}
+	for _, d := range []data.Pokedex{data.DexKanto, data.DexJohto, data.DexHoenn} {
+		pr := t.ByDex(data.AllPokemon, d)
+		_, err := fmt.Fprintf(w, "  %-8s  caught %3d  seen %3d  of %3d\n", d, pr.Caught, pr.Seen, pr.Total)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// KeysFileName is the key bindings file inside Dir.
+const KeysFileName = "keys.json"
+
+// Keys configures the key bindings: a preset, and keys for single actions
+// that replace the preset's. A missing file or empty fields mean the
+// default bindings.
+type Keys struct {
+	// Preset is "default", "vim" or "emacs".
+	Preset string `json:"preset,omitempty"`
+	// Bindings maps action names, like "up" or "quit", to their keys, named
+	// as in "k", "ctrl+n", "shift+tab" or "space".
+	Bindings map[string][]string `json:"bindings,omitempty"`
+}
+
+// KeysPath returns the key bindings file in the user's config directory.
+func KeysPath() (string, error) {
+	return Path(KeysFileName)
+}
+
+// LoadKeys reads the key bindings at path. A missing file yields the
+// defaults.
+func LoadKeys(path string) (Keys, error) {
+	var k Keys
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return k, nil
+	}
+	if err != nil {
+		return k, err
+	}
+	if err := json.Unmarshal(b, &k); err != nil {
+		return k, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return k, nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), KeysFileName)
+
+	k, err := LoadKeys(path)
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if k.Preset != "" || k.Bindings != nil {
+		t.Errorf("missing file = %+v, want defaults", k)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"preset": "vim", "bindings": {"quit": ["ctrl+c"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	k, err = LoadKeys(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Preset != "vim" || !slices.Equal(k.Bindings["quit"], []string{"ctrl+c"}) {
+		t.Errorf("loaded %+v", k)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"bindings": ["up"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKeys(path); err == nil {
+		t.Error("expected an error for malformed bindings")
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SettingsFileName is the settings file inside Dir.
+const SettingsFileName = "settings.json"
+
+// Settings are the user's display preferences, remembered between sessions.
+// Empty fields mean the default.
+type Settings struct {
+	// Language is the display language for names, as accepted by
+	// data.ParseLanguage.
+	Language string `json:"language,omitempty"`
+	// Sort is the search list's sort mode, as accepted by
+	// search.ParseSortMode.
+	Sort string `json:"sort,omitempty"`
+	// Version is the game version detail screens open on, as accepted by
+	// data.ParseGameVersion.
+	Version string `json:"version,omitempty"`
+}
+
+// SettingsPath returns the settings file in the user's config directory.
+func SettingsPath() (string, error) {
+	return Path(SettingsFileName)
+}
+
+// LoadSettings reads the settings at path. A missing file yields the defaults.
+func LoadSettings(path string) (Settings, error) {
+	var s Settings
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// SaveSettings writes s to path atomically.
+func SaveSettings(path string, s Settings) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, append(b, '\n'), 0644)
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSettings_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SettingsFileName)
+
+	s, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if s != (Settings{}) {
+		t.Errorf("missing file = %+v, want defaults", s)
+	}
+
+	if err := SaveSettings(path, Settings{Language: "fr", Version: "FireRed"}); err != nil {
+		t.Fatal(err)
+	}
+	s, err = LoadSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Language != "fr" {
+		t.Errorf("Language = %q, want \"fr\"", s.Language)
+	}
+	if s.Version != "FireRed" {
+		t.Errorf("Version = %q, want \"FireRed\"", s.Version)
+	}
+}
+
+func TestLoadSettings_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SettingsFileName)
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(path); err == nil {
+		t.Error("expected an error for a malformed settings file")
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ThemeFileName is the color theme file inside Dir.
+const ThemeFileName = "theme.json"
+
+// Theme configures the colors: a named theme, and colors for single roles
+// that replace the theme's. A missing file or empty fields mean the default
+// theme, which follows the terminal's light or dark background.
+type Theme struct {
+	// Name is "dark", "light", "high-contrast" or "monochrome".
+	Name string `json:"name,omitempty"`
+	// Colors maps role names, like "header" or a type such as "fire", to
+	// colors as "#rrggbb" or an ANSI color number from 0 to 255.
+	Colors map[string]string `json:"colors,omitempty"`
+}
+
+// ThemePath returns the theme file in the user's config directory.
+func ThemePath() (string, error) {
+	return Path(ThemeFileName)
+}
+
+// LoadTheme reads the theme at path. A missing file yields the default.
+func LoadTheme(path string) (Theme, error) {
+	var t Theme
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return t, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return t, nil
+}
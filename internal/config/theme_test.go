@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ThemeFileName)
+
+	th, err := LoadTheme(path)
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if th.Name != "" || th.Colors != nil {
+		t.Errorf("missing file = %+v, want the default", th)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name": "light", "colors": {"fire": "#ff4400"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	th, err = LoadTheme(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Name != "light" || th.Colors["fire"] != "#ff4400" {
+		t.Errorf("loaded %+v", th)
+	}
+}
@@ -1,5 +1,10 @@
 package data
 
+import (
+	"strings"
+	"unicode"
+)
+
 //go:generate go run ../../cmd/gencmd/main.go -data ../../_data/api-data/data/api/v2 -out .
 
 // AllPokemon is the ordered dex slice; appended to by generated init() in pokemon_gen.go.
@@ -11,9 +16,15 @@ var AllMoves []*Move
 // AllAbilities is indexed by AbilityID; slot 0 unused. Populated by abilities_gen.go init().
 var AllAbilities []*Ability
 
+// Aliases maps English display names that differ from the dex name, such as
+// "Mr. Mime" or "Nidoran♀", to the dex name. Populated by aliases_gen.go init().
+var Aliases map[string]string
+
 // ByID and ByName are built after all generated init() blocks have run.
+// ByAlias is keyed by NormalizeName of both the dex name and its aliases.
 var ByID map[uint16]*Pokemon
 var ByName map[string]*Pokemon
+var ByAlias map[string]*Pokemon
 
 func init() {
 	ByID = make(map[uint16]*Pokemon, len(AllPokemon))
@@ -22,4 +33,138 @@ func init() {
 		ByID[p.ID] = p
 		ByName[p.Name] = p
 	}
+	ByAlias = buildAliases(AllPokemon, Aliases)
+}
+
+// buildAliases indexes pokemon by their normalized dex names and aliases.
+func buildAliases(pokemon []*Pokemon, aliases map[string]string) map[string]*Pokemon {
+	byAlias := make(map[string]*Pokemon, len(pokemon)+len(aliases))
+	byName := make(map[string]*Pokemon, len(pokemon))
+	for _, p := range pokemon {
+		byName[p.Name] = p
+		byAlias[NormalizeName(p.Name)] = p
+	}
+	for alias, name := range aliases {
+		if p, ok := byName[name]; ok {
+			byAlias[NormalizeName(alias)] = p
+		}
+	}
+	return byAlias
+}
+
+// FoldRune reduces r to the form NormalizeName compares: lowercase, without
+// accents, gender symbols spelled out and hiragana as katakana. It reports
+// false for punctuation, spaces and other runes NormalizeName drops.
+func FoldRune(r rune) (rune, bool) {
+	switch {
+	case r == '♀':
+		return 'f', true
+	case r == '♂':
+		return 'm', true
+	case r >= 'ぁ' && r <= 'ゖ':
+		return r + ('ァ' - 'ぁ'), true
+	case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != 'ー':
+		return 0, false
+	}
+	r = unicode.ToLower(r)
+	if base, ok := unaccented[r]; ok {
+		return base, true
+	}
+	return r, true
+}
+
+// unaccented maps the accented letters in French, German, Spanish and
+// Italian names to their base letter.
+var unaccented = map[rune]rune{
+	'à': 'a', 'â': 'a', 'ä': 'a', 'á': 'a',
+	'ç': 'c',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ñ': 'n',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+}
+
+// NormalizeName reduces a name to folded letters and digits (see FoldRune)
+// so spellings that differ only in case, punctuation, spaces, accents, gender
+// symbols or kana script agree: "Mr. Mime", "mr-mime" and "MrMime" all
+// become "mrmime", and "ひとかげ" matches "ヒトカゲ".
+func NormalizeName(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if f, ok := FoldRune(r); ok {
+			sb.WriteRune(f)
+		}
+	}
+	return sb.String()
+}
+
+// DisplayName turns a hyphenated dex name into words: "thunder-punch" →
+// "Thunder Punch".
+func DisplayName(name string) string {
+	words := strings.Fields(strings.ReplaceAll(name, "-", " "))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// EnglishName returns the English name in names, such as "Mr. Mime", or the
+// dex name as DisplayName words when the data has none.
+func EnglishName(name string, names LocalNames) string {
+	if n := names[LangEnglish]; n != "" {
+		return n
+	}
+	return DisplayName(name)
+}
+
+// MoveByID returns the move with the given ID, or nil if it isn't loaded.
+func MoveByID(id MoveID) *Move {
+	if int(id) >= len(AllMoves) {
+		return nil
+	}
+	return AllMoves[id]
+}
+
+// CatchableIn reports whether p has a wild encounter in version v.
+func (p *Pokemon) CatchableIn(v GameVersion) bool {
+	for _, loc := range p.Locations {
+		if loc.Game == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ObtainableIn reports whether p exists in version v and can be caught
+// there, or evolved from a species that can, following EvolvesFrom through
+// ByID. Gifts and in-game trades aren't in the encounter data, so species
+// only obtained that way don't count.
+func (p *Pokemon) ObtainableIn(v GameVersion) bool {
+	if IntroducedIn(p.ID) > GenForVersion(v) {
+		return false
+	}
+	for sp := p; sp != nil; sp = ByID[sp.EvolvesFrom] {
+		if sp.CatchableIn(v) {
+			return true
+		}
+		if sp.EvolvesFrom == 0 {
+			break
+		}
+	}
+	return false
+}
+
+// EvolutionStage returns 1 for a base form, 2 for what it evolves into and
+// 3 for the evolution after that, following EvolvesFrom through ByID.
+func (p *Pokemon) EvolutionStage() int {
+	stage := 1
+	for id := p.EvolvesFrom; id != 0; stage++ {
+		parent := ByID[id]
+		if parent == nil {
+			return stage + 1
+		}
+		id = parent.EvolvesFrom
+	}
+	return stage
 }
@@ -0,0 +1,171 @@
+package data
+
+// Effectiveness is a damage multiplier in quarters, so that the product for a
+// dual-type defender stays exact: 0 (no effect), 1 (¼×), 2 (½×), 4 (1×),
+// 8 (2×) or 16 (4×).
+type Effectiveness uint8
+
+const (
+	NoEffect         Effectiveness = 0
+	QuarterEffective Effectiveness = 1
+	NotVeryEffective Effectiveness = 2
+	Neutral          Effectiveness = 4
+	SuperEffective   Effectiveness = 8
+	DoubleEffective  Effectiveness = 16
+)
+
+func (e Effectiveness) String() string {
+	switch e {
+	case NoEffect:
+		return "0×"
+	case QuarterEffective:
+		return "¼×"
+	case NotVeryEffective:
+		return "½×"
+	case SuperEffective:
+		return "2×"
+	case DoubleEffective:
+		return "4×"
+	}
+	return "1×"
+}
+
+// typeMatchup is one attacker × defender pair that isn't neutral.
+type typeMatchup struct {
+	attacker, defender PokeType
+	e                  Effectiveness
+}
+
+// matchups are the Gen 2-5 chart's non-neutral pairs; Gen 3 didn't change it.
+var matchups = []typeMatchup{
+	{TypeNormal, TypeRock, NotVeryEffective}, {TypeNormal, TypeGhost, NoEffect}, {TypeNormal, TypeSteel, NotVeryEffective},
+
+	{TypeFire, TypeFire, NotVeryEffective}, {TypeFire, TypeWater, NotVeryEffective}, {TypeFire, TypeGrass, SuperEffective},
+	{TypeFire, TypeIce, SuperEffective}, {TypeFire, TypeBug, SuperEffective}, {TypeFire, TypeRock, NotVeryEffective},
+	{TypeFire, TypeDragon, NotVeryEffective}, {TypeFire, TypeSteel, SuperEffective},
+
+	{TypeWater, TypeFire, SuperEffective}, {TypeWater, TypeWater, NotVeryEffective}, {TypeWater, TypeGrass, NotVeryEffective},
+	{TypeWater, TypeGround, SuperEffective}, {TypeWater, TypeRock, SuperEffective}, {TypeWater, TypeDragon, NotVeryEffective},
+
+	{TypeGrass, TypeFire, NotVeryEffective}, {TypeGrass, TypeWater, SuperEffective}, {TypeGrass, TypeGrass, NotVeryEffective},
+	{TypeGrass, TypePoison, NotVeryEffective}, {TypeGrass, TypeGround, SuperEffective}, {TypeGrass, TypeFlying, NotVeryEffective},
+	{TypeGrass, TypeBug, NotVeryEffective}, {TypeGrass, TypeRock, SuperEffective}, {TypeGrass, TypeDragon, NotVeryEffective},
+	{TypeGrass, TypeSteel, NotVeryEffective},
+
+	{TypeElectric, TypeWater, SuperEffective}, {TypeElectric, TypeGrass, NotVeryEffective},
+	{TypeElectric, TypeElectric, NotVeryEffective}, {TypeElectric, TypeGround, NoEffect},
+	{TypeElectric, TypeFlying, SuperEffective}, {TypeElectric, TypeDragon, NotVeryEffective},
+
+	{TypeIce, TypeFire, NotVeryEffective}, {TypeIce, TypeWater, NotVeryEffective}, {TypeIce, TypeGrass, SuperEffective},
+	{TypeIce, TypeIce, NotVeryEffective}, {TypeIce, TypeGround, SuperEffective}, {TypeIce, TypeFlying, SuperEffective},
+	{TypeIce, TypeDragon, SuperEffective}, {TypeIce, TypeSteel, NotVeryEffective},
+
+	{TypeFighting, TypeNormal, SuperEffective}, {TypeFighting, TypeIce, SuperEffective},
+	{TypeFighting, TypePoison, NotVeryEffective}, {TypeFighting, TypeFlying, NotVeryEffective},
+	{TypeFighting, TypePsychic, NotVeryEffective}, {TypeFighting, TypeBug, NotVeryEffective},
+	{TypeFighting, TypeRock, SuperEffective}, {TypeFighting, TypeGhost, NoEffect},
+	{TypeFighting, TypeDark, SuperEffective}, {TypeFighting, TypeSteel, SuperEffective},
+
+	{TypePoison, TypeGrass, SuperEffective}, {TypePoison, TypePoison, NotVeryEffective},
+	{TypePoison, TypeGround, NotVeryEffective}, {TypePoison, TypeRock, NotVeryEffective},
+	{TypePoison, TypeGhost, NotVeryEffective}, {TypePoison, TypeSteel, NoEffect},
+
+	{TypeGround, TypeFire, SuperEffective}, {TypeGround, TypeGrass, NotVeryEffective},
+	{TypeGround, TypeElectric, SuperEffective}, {TypeGround, TypePoison, SuperEffective},
+	{TypeGround, TypeFlying, NoEffect}, {TypeGround, TypeBug, NotVeryEffective},
+	{TypeGround, TypeRock, SuperEffective}, {TypeGround, TypeSteel, SuperEffective},
+
+	{TypeFlying, TypeGrass, SuperEffective}, {TypeFlying, TypeElectric, NotVeryEffective},
+	{TypeFlying, TypeFighting, SuperEffective}, {TypeFlying, TypeBug, SuperEffective},
+	{TypeFlying, TypeRock, NotVeryEffective}, {TypeFlying, TypeSteel, NotVeryEffective},
+
+	{TypePsychic, TypeFighting, SuperEffective}, {TypePsychic, TypePoison, SuperEffective},
+	{TypePsychic, TypePsychic, NotVeryEffective}, {TypePsychic, TypeDark, NoEffect},
+	{TypePsychic, TypeSteel, NotVeryEffective},
+
+	{TypeBug, TypeFire, NotVeryEffective}, {TypeBug, TypeGrass, SuperEffective}, {TypeBug, TypeFighting, NotVeryEffective},
+	{TypeBug, TypePoison, NotVeryEffective}, {TypeBug, TypeFlying, NotVeryEffective}, {TypeBug, TypePsychic, SuperEffective},
+	{TypeBug, TypeGhost, NotVeryEffective}, {TypeBug, TypeDark, SuperEffective}, {TypeBug, TypeSteel, NotVeryEffective},
+
+	{TypeRock, TypeFire, SuperEffective}, {TypeRock, TypeIce, SuperEffective}, {TypeRock, TypeFighting, NotVeryEffective},
+	{TypeRock, TypeGround, NotVeryEffective}, {TypeRock, TypeFlying, SuperEffective}, {TypeRock, TypeBug, SuperEffective},
+	{TypeRock, TypeSteel, NotVeryEffective},
+
+	{TypeGhost, TypeNormal, NoEffect}, {TypeGhost, TypePsychic, SuperEffective}, {TypeGhost, TypeGhost, SuperEffective},
+	{TypeGhost, TypeDark, NotVeryEffective}, {TypeGhost, TypeSteel, NotVeryEffective},
+
+	{TypeDragon, TypeDragon, SuperEffective}, {TypeDragon, TypeSteel, NotVeryEffective},
+
+	{TypeDark, TypeFighting, NotVeryEffective}, {TypeDark, TypePsychic, SuperEffective}, {TypeDark, TypeGhost, SuperEffective},
+	{TypeDark, TypeDark, NotVeryEffective}, {TypeDark, TypeSteel, NotVeryEffective},
+
+	{TypeSteel, TypeFire, NotVeryEffective}, {TypeSteel, TypeWater, NotVeryEffective},
+	{TypeSteel, TypeElectric, NotVeryEffective}, {TypeSteel, TypeIce, SuperEffective},
+	{TypeSteel, TypeRock, SuperEffective}, {TypeSteel, TypeSteel, NotVeryEffective},
+}
+
+// gen1Matchups are the pairs Gen 2 changed, as they were in Gen 1, including
+// the Ghost → Psychic immunity the games' code gave by mistake.
+var gen1Matchups = []typeMatchup{
+	{TypeBug, TypePoison, SuperEffective},
+	{TypePoison, TypeBug, SuperEffective},
+	{TypeGhost, TypePsychic, NoEffect},
+	{TypeIce, TypeFire, Neutral},
+}
+
+// typeCharts holds the Gen 1 chart at 0 and the Gen 2-3 chart at 1.
+var typeCharts = func() [2][TypeSteel + 1][TypeSteel + 1]Effectiveness {
+	var charts [2][TypeSteel + 1][TypeSteel + 1]Effectiveness
+	for c := range charts {
+		for a := range charts[c] {
+			for d := range charts[c][a] {
+				charts[c][a][d] = Neutral
+			}
+		}
+		for _, m := range matchups {
+			charts[c][m.attacker][m.defender] = m.e
+		}
+	}
+	for _, m := range gen1Matchups {
+		charts[0][m.attacker][m.defender] = m.e
+	}
+	return charts
+}()
+
+// TypesInGen returns the types that exist in a generation: Dark and Steel
+// arrived in Gen 2.
+func TypesInGen(gen Generation) []PokeType {
+	last := TypeSteel
+	if gen <= 1 {
+		last = TypeDragon
+	}
+	var types []PokeType
+	for t := TypeNormal; t <= last; t++ {
+		types = append(types, t)
+	}
+	return types
+}
+
+// TypeEffectiveness returns the multiplier for a move of the attacker type
+// against a defender of one type in a generation. TypeNone on either side
+// is neutral.
+func TypeEffectiveness(attacker, defender PokeType, gen Generation) Effectiveness {
+	if attacker == TypeNone || defender == TypeNone || attacker > TypeSteel || defender > TypeSteel {
+		return Neutral
+	}
+	chart := 1
+	if gen <= 1 {
+		chart = 0
+	}
+	return typeCharts[chart][attacker][defender]
+}
+
+// DualEffectiveness returns the multiplier against a defender with up to two
+// types, the product of each type's; a TypeNone slot is ignored.
+func DualEffectiveness(attacker PokeType, defender [2]PokeType, gen Generation) Effectiveness {
+	e := TypeEffectiveness(attacker, defender[0], gen)
+	if defender[1] != defender[0] {
+		e = e * TypeEffectiveness(attacker, defender[1], gen) / Neutral
+	}
+	return e
+}
@@ -0,0 +1,74 @@
+package data
+
+import "testing"
+
+func TestTypeEffectiveness(t *testing.T) {
+	tests := []struct {
+		attacker, defender PokeType
+		gen                Generation
+		want               Effectiveness
+	}{
+		{TypeFire, TypeGrass, 3, SuperEffective},
+		{TypeWater, TypeDragon, 3, NotVeryEffective},
+		{TypeElectric, TypeGround, 3, NoEffect},
+		{TypeNormal, TypeNormal, 3, Neutral},
+		{TypeDark, TypeGhost, 2, SuperEffective},
+		{TypeGhost, TypeSteel, 2, NotVeryEffective},
+		// Gen 1 differences
+		{TypeGhost, TypePsychic, 1, NoEffect},
+		{TypeGhost, TypePsychic, 2, SuperEffective},
+		{TypeBug, TypePoison, 1, SuperEffective},
+		{TypeBug, TypePoison, 2, NotVeryEffective},
+		{TypePoison, TypeBug, 1, SuperEffective},
+		{TypePoison, TypeBug, 3, Neutral},
+		{TypeIce, TypeFire, 1, Neutral},
+		{TypeIce, TypeFire, 3, NotVeryEffective},
+		{TypeNone, TypeFire, 3, Neutral},
+	}
+	for _, tt := range tests {
+		if got := TypeEffectiveness(tt.attacker, tt.defender, tt.gen); got != tt.want {
+			t.Errorf("TypeEffectiveness(%v, %v, %d) = %v, want %v", tt.attacker, tt.defender, tt.gen, got, tt.want)
+		}
+	}
+}
+
+func TestDualEffectiveness(t *testing.T) {
+	tests := []struct {
+		attacker PokeType
+		defender [2]PokeType
+		want     Effectiveness
+	}{
+		{TypeRock, [2]PokeType{TypeFire, TypeFlying}, DoubleEffective},
+		{TypeGround, [2]PokeType{TypeFire, TypeFlying}, NoEffect},
+		{TypeWater, [2]PokeType{TypeFire, TypeFlying}, SuperEffective},
+		{TypeFire, [2]PokeType{TypeWater, TypeDragon}, QuarterEffective},
+		{TypeFire, [2]PokeType{TypeGrass, TypeNone}, SuperEffective},
+		{TypeFire, [2]PokeType{TypeGrass, TypeGrass}, SuperEffective},
+		{TypeFighting, [2]PokeType{TypeNormal, TypeGhost}, NoEffect},
+	}
+	for _, tt := range tests {
+		if got := DualEffectiveness(tt.attacker, tt.defender, 3); got != tt.want {
+			t.Errorf("DualEffectiveness(%v, %v) = %v, want %v", tt.attacker, tt.defender, got, tt.want)
+		}
+	}
+}
+
+func TestTypeChart_NoDuplicatePairs(t *testing.T) {
+	seen := make(map[[2]PokeType]bool)
+	for _, m := range matchups {
+		k := [2]PokeType{m.attacker, m.defender}
+		if seen[k] {
+			t.Errorf("%v → %v listed twice", m.attacker, m.defender)
+		}
+		seen[k] = true
+	}
+}
+
+func TestTypesInGen(t *testing.T) {
+	if n := len(TypesInGen(1)); n != 15 {
+		t.Errorf("Gen 1 has %d types, want 15", n)
+	}
+	if n := len(TypesInGen(2)); n != 17 {
+		t.Errorf("Gen 2 has %d types, want 17", n)
+	}
+}
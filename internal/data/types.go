@@ -1,5 +1,7 @@
 package data
 
+import "strings"
+
 // PokeType is a single byte; 17 types fit with room to spare.
 type PokeType byte
 
@@ -32,6 +34,16 @@ var typeNames = [18]string{
 
 func (t PokeType) String() string { return typeNames[t] }
 
+// ParsePokeType looks up a type by its display name, case-insensitively.
+func ParsePokeType(name string) (PokeType, bool) {
+	for i := TypeNormal; i <= TypeSteel; i++ {
+		if strings.EqualFold(typeNames[i], name) {
+			return i, true
+		}
+	}
+	return TypeNone, false
+}
+
 // MoveCategory fits in 2 bits; using byte.
 type MoveCategory byte
 
@@ -55,6 +67,10 @@ const (
 	LearnEgg     LearnMethod = 3
 )
 
+var learnMethodNames = [4]string{"Level", "TM/HM", "Tutor", "Egg"}
+
+func (l LearnMethod) String() string { return learnMethodNames[l] }
+
 // GameVersion fits in 4 bits; using byte.
 type GameVersion byte
 
@@ -79,6 +95,76 @@ var versionNames = [12]string{
 
 func (v GameVersion) String() string { return versionNames[v] }
 
+// ParseGameVersion looks up a version by its display name, case-insensitively.
+func ParseGameVersion(name string) (GameVersion, bool) {
+	for i := GameRed; i <= GameLeafGreen; i++ {
+		if strings.EqualFold(versionNames[i], name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Pokedex identifies the national or a regional dex; fits in 2 bits, using byte.
+type Pokedex byte
+
+const (
+	DexNational Pokedex = 0
+	DexKanto    Pokedex = 1
+	DexJohto    Pokedex = 2
+	DexHoenn    Pokedex = 3
+)
+
+var pokedexNames = [4]string{"National", "Kanto", "Johto", "Hoenn"}
+
+func (d Pokedex) String() string {
+	if int(d) < len(pokedexNames) {
+		return pokedexNames[d]
+	}
+	return "Unknown"
+}
+
+// Language is a language names are available in; fits in 3 bits, using byte.
+type Language byte
+
+const (
+	LangEnglish  Language = 0
+	LangJapanese Language = 1 // kana
+	LangRomaji   Language = 2 // Japanese names in Latin letters
+	LangFrench   Language = 3
+	LangGerman   Language = 4
+	LangSpanish  Language = 5
+	LangItalian  Language = 6
+	LangKorean   Language = 7
+)
+
+// NumLanguages is the number of Language values.
+const NumLanguages = 8
+
+var languageNames = [NumLanguages]string{"English", "Japanese", "Romaji", "French", "German", "Spanish", "Italian", "Korean"}
+
+// languageCodes are the short codes accepted by ParseLanguage.
+var languageCodes = [NumLanguages]string{"en", "ja", "romaji", "fr", "de", "es", "it", "ko"}
+
+func (l Language) String() string { return languageNames[l] }
+
+// Code returns the short code for l, e.g. "fr".
+func (l Language) Code() string { return languageCodes[l] }
+
+// ParseLanguage looks up a language by its name or code, case-insensitively.
+func ParseLanguage(name string) (Language, bool) {
+	for i := range Language(NumLanguages) {
+		if strings.EqualFold(languageNames[i], name) || strings.EqualFold(languageCodes[i], name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// LocalNames holds a name in each Language, indexed by Language; a name is
+// empty when the data has none for that language.
+type LocalNames [NumLanguages]string
+
 // EncounterMethod fits in 3 bits; using byte.
 type EncounterMethod byte
 
@@ -118,6 +204,18 @@ func GenForVersion(v GameVersion) Generation {
 	}
 }
 
+// IntroducedIn returns the generation a national dex number first appeared in.
+func IntroducedIn(id uint16) Generation {
+	switch {
+	case id <= 151:
+		return 1
+	case id <= 251:
+		return 2
+	default:
+		return 3
+	}
+}
+
 // MoveID uniquely identifies a move. uint16 handles all current + future gens.
 type MoveID uint16
 
@@ -131,16 +229,27 @@ type MoveTypePast struct {
 	Type     PokeType
 }
 
+// MoveValuesPast records a move's power, accuracy and PP before a change;
+// a zero field didn't change then.
+type MoveValuesPast struct {
+	UntilGen Generation
+	Power    uint8
+	Accuracy uint8
+	PP       uint8
+}
+
 // Move is stored once in AllMoves; LearnedMove references it by MoveID.
 type Move struct {
-	ID        MoveID
-	Name      string
-	Type      PokeType
-	Category  MoveCategory
-	Power     uint8
-	Accuracy  uint8
-	PP        uint8
-	PastTypes []MoveTypePast
+	ID         MoveID
+	Name       string
+	Type       PokeType
+	Category   MoveCategory
+	Power      uint8
+	Accuracy   uint8
+	PP         uint8
+	PastTypes  []MoveTypePast
+	PastValues []MoveValuesPast // in the order the changes happened
+	Names      LocalNames
 }
 
 // TypeForGen returns the move's type for a given generation.
@@ -153,6 +262,40 @@ func (m *Move) TypeForGen(gen Generation) PokeType {
 	return m.Type
 }
 
+// IntroducedIn returns the generation the move first appeared in.
+func (m *Move) IntroducedIn() Generation {
+	switch {
+	case m.ID <= 165:
+		return 1
+	case m.ID <= 251:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// ValuesForGen returns the move's power, accuracy and PP in a given
+// generation: for each, the value before the first later change to it.
+func (m *Move) ValuesForGen(gen Generation) (power, accuracy, pp uint8) {
+	power, accuracy, pp = m.Power, m.Accuracy, m.PP
+	var gotPower, gotAccuracy, gotPP bool
+	for _, pv := range m.PastValues {
+		if gen > pv.UntilGen {
+			continue
+		}
+		if pv.Power != 0 && !gotPower {
+			power, gotPower = pv.Power, true
+		}
+		if pv.Accuracy != 0 && !gotAccuracy {
+			accuracy, gotAccuracy = pv.Accuracy, true
+		}
+		if pv.PP != 0 && !gotPP {
+			pp, gotPP = pv.PP, true
+		}
+	}
+	return power, accuracy, pp
+}
+
 // preGen3PhysicalTypes maps PokeType to Physical for the Gen 1-2 type-based split.
 // Physical: Normal, Fighting, Poison, Ground, Flying, Rock, Ghost, Bug, Dark, Steel.
 // Dark and Steel were introduced in Gen 2 and are Physical in the type-split.
@@ -197,6 +340,7 @@ type Ability struct {
 	ID        AbilityID
 	Name      string
 	ShortDesc string
+	Names     LocalNames
 }
 
 // LearnedMove references the global AllMoves table by MoveID.
@@ -226,6 +370,87 @@ type Location struct {
 	AreaName        string
 }
 
+// EvolutionTrigger fits in 3 bits; using byte.
+type EvolutionTrigger byte
+
+const (
+	EvolveNone    EvolutionTrigger = 0
+	EvolveLevelUp EvolutionTrigger = 1
+	EvolveTrade   EvolutionTrigger = 2
+	EvolveItem    EvolutionTrigger = 3
+	EvolveOther   EvolutionTrigger = 4
+)
+
+var evolutionTriggerNames = [5]string{"", "Level up", "Trade", "Use item", "Other"}
+
+func (e EvolutionTrigger) String() string {
+	if int(e) < len(evolutionTriggerNames) {
+		return evolutionTriggerNames[e]
+	}
+	return "Unknown"
+}
+
+// GrowthRate fits in 3 bits; using byte. The zero value is Medium Fast.
+type GrowthRate byte
+
+const (
+	GrowthMediumFast  GrowthRate = 0
+	GrowthErratic     GrowthRate = 1
+	GrowthFluctuating GrowthRate = 2
+	GrowthMediumSlow  GrowthRate = 3
+	GrowthFast        GrowthRate = 4
+	GrowthSlow        GrowthRate = 5
+)
+
+// ExpForLevel returns the total experience needed to reach level n (1-100).
+func (g GrowthRate) ExpForLevel(n int) uint32 {
+	if n <= 1 {
+		return 0
+	}
+	cube := n * n * n
+	var exp int
+	switch g {
+	case GrowthErratic:
+		switch {
+		case n < 50:
+			exp = cube * (100 - n) / 50
+		case n < 68:
+			exp = cube * (150 - n) / 100
+		case n < 98:
+			exp = cube * ((1911 - 10*n) / 3) / 500
+		default:
+			exp = cube * (160 - n) / 100
+		}
+	case GrowthFluctuating:
+		switch {
+		case n < 15:
+			exp = cube * ((n+1)/3 + 24) / 50
+		case n < 36:
+			exp = cube * (n + 14) / 50
+		default:
+			exp = cube * (n/2 + 32) / 50
+		}
+	case GrowthMediumSlow:
+		exp = 6*cube/5 - 15*n*n + 100*n - 140
+	case GrowthFast:
+		exp = 4 * cube / 5
+	case GrowthSlow:
+		exp = 5 * cube / 4
+	default:
+		exp = cube
+	}
+	return uint32(exp)
+}
+
+// LevelForExp returns the level reached with the given total experience.
+func (g GrowthRate) LevelForExp(exp uint32) uint8 {
+	level := 1
+	for level < 100 && g.ExpForLevel(level+1) <= exp {
+		level++
+	}
+	return uint8(level)
+}
+
 // BaseStats holds the six base stats; all fit in uint8.
 type BaseStats struct {
 	HP, Attack, Defense, SpecialAttack, SpecialDefense, Speed uint8
@@ -247,8 +472,22 @@ type Pokemon struct {
 	Height    uint16
 	Weight    uint16
 	Abilities [2]AbilityID
-	Moves     []VersionedLearnset
-	Locations []Location
+	// HiddenAbility is 0 when the species has none. Hidden abilities only
+	// arrived in Gen 5, so no Gen 1-3 game gives a Pokémon this one, and
+	// it's only in AllAbilities when some species has it in a normal slot.
+	HiddenAbility AbilityID
+	Moves         []VersionedLearnset
+	Locations     []Location
+	// EvolvesFrom is the national dex ID of the pre-evolution; 0 for base forms.
+	EvolvesFrom      uint16
+	EvolutionTrigger EvolutionTrigger
+	EvolutionLevel   uint8
+	// DexNumbers holds the Kanto, Johto and Hoenn numbers; 0 = not in that dex.
+	DexNumbers [3]uint16
+	GrowthRate GrowthRate
+	// CaptureRate is the species catch rate, 3 to 255; 0 when unknown.
+	CaptureRate uint8
+	Names       LocalNames
 }
 
 // TypesForGen returns the Pokemon's types for a given generation.
@@ -260,3 +499,14 @@ func (p *Pokemon) TypesForGen(gen Generation) [2]PokeType {
 	}
 	return p.Types
 }
+
+// DexNumber returns p's number in dex d, or 0 if it isn't listed there.
+func (p *Pokemon) DexNumber(d Pokedex) uint16 {
+	if d == DexNational {
+		return p.ID
+	}
+	if int(d) > len(p.DexNumbers) {
+		return 0
+	}
+	return p.DexNumbers[d-1]
+}
@@ -61,6 +61,51 @@ func TestMoveTypeForGen_ChangedMove(t *testing.T) {
 	}
 }
 
+// Move.ValuesForGen tests
+
+func TestMoveValuesForGen(t *testing.T) {
+	// Dig was 100 power in Gen 1 and 60 from Gen 2 until Diamond/Pearl.
+	dig := &Move{
+		ID: 91, Name: "Dig", Power: 80, Accuracy: 100, PP: 10,
+		PastValues: []MoveValuesPast{{UntilGen: 1, Power: 100}, {UntilGen: 3, Power: 60}},
+	}
+	// Tackle changed twice after Gen 3; the earlier change wins.
+	tackle := &Move{
+		ID: 33, Name: "Tackle", Power: 40, Accuracy: 100, PP: 35,
+		PastValues: []MoveValuesPast{{UntilGen: 3, Power: 35, Accuracy: 95}, {UntilGen: 3, Power: 50}},
+	}
+	tests := []struct {
+		move           *Move
+		gen            Generation
+		power, acc, pp uint8
+	}{
+		{dig, 1, 100, 100, 10},
+		{dig, 2, 60, 100, 10},
+		{dig, 3, 60, 100, 10},
+		{tackle, 1, 35, 95, 35},
+		{tackle, 3, 35, 95, 35},
+	}
+	for _, tt := range tests {
+		power, acc, pp := tt.move.ValuesForGen(tt.gen)
+		if power != tt.power || acc != tt.acc || pp != tt.pp {
+			t.Errorf("%s.ValuesForGen(%d) = %d, %d, %d; want %d, %d, %d",
+				tt.move.Name, tt.gen, power, acc, pp, tt.power, tt.acc, tt.pp)
+		}
+	}
+}
+
+func TestMoveIntroducedIn(t *testing.T) {
+	tests := []struct {
+		id   MoveID
+		want Generation
+	}{{1, 1}, {165, 1}, {166, 2}, {251, 2}, {252, 3}, {354, 3}}
+	for _, tt := range tests {
+		if got := (&Move{ID: tt.id}).IntroducedIn(); got != tt.want {
+			t.Errorf("Move %d IntroducedIn = %d, want %d", tt.id, got, tt.want)
+		}
+	}
+}
+
 // Move.CategoryForGen tests
 
 func TestMoveCategoryForGen_Gen3PerMove(t *testing.T) {
@@ -177,3 +222,217 @@ func TestPokemonTypesForGen_Changed(t *testing.T) {
 		t.Errorf("Magnemite.TypesForGen(3) = %v, want [Electric, Steel]", gen3)
 	}
 }
+
+// ParsePokeType tests
+
+func TestParsePokeType(t *testing.T) {
+	for in, want := range map[string]PokeType{"fire": TypeFire, "Steel": TypeSteel, "NORMAL": TypeNormal} {
+		if got, ok := ParsePokeType(in); !ok || got != want {
+			t.Errorf("ParsePokeType(%q) = %v, %v; want %v, true", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "fairy"} {
+		if _, ok := ParsePokeType(in); ok {
+			t.Errorf("ParsePokeType(%q) ok = true, want false", in)
+		}
+	}
+}
+
+// ParseLanguage tests
+
+func TestParseLanguage(t *testing.T) {
+	for in, want := range map[string]Language{"English": LangEnglish, "ja": LangJapanese, "FR": LangFrench, "romaji": LangRomaji, "korean": LangKorean} {
+		if got, ok := ParseLanguage(in); !ok || got != want {
+			t.Errorf("ParseLanguage(%q) = %v, %v; want %v, true", in, got, ok, want)
+		}
+	}
+	if _, ok := ParseLanguage("klingon"); ok {
+		t.Error("ParseLanguage(\"klingon\") ok = true, want false")
+	}
+}
+
+// ParseGameVersion tests
+
+func TestParseGameVersion(t *testing.T) {
+	cases := map[string]GameVersion{
+		"Red":       GameRed,
+		"red":       GameRed,
+		"FIRERED":   GameFireRed,
+		"leafgreen": GameLeafGreen,
+	}
+	for in, want := range cases {
+		got, ok := ParseGameVersion(in)
+		if !ok || got != want {
+			t.Errorf("ParseGameVersion(%q) = %v, %v; want %v, true", in, got, ok, want)
+		}
+	}
+	if _, ok := ParseGameVersion("diamond"); ok {
+		t.Error("ParseGameVersion(\"diamond\") ok = true, want false")
+	}
+}
+
+// IntroducedIn tests
+
+func TestIntroducedIn(t *testing.T) {
+	cases := []struct {
+		id   uint16
+		want Generation
+	}{
+		{1, 1}, {151, 1}, {152, 2}, {251, 2}, {252, 3}, {386, 3},
+	}
+	for _, c := range cases {
+		if got := IntroducedIn(c.id); got != c.want {
+			t.Errorf("IntroducedIn(%d) = %d, want %d", c.id, got, c.want)
+		}
+	}
+}
+
+// Pokemon.DexNumber tests
+
+func TestPokemonDexNumber(t *testing.T) {
+	magnemite := &Pokemon{ID: 81, Name: "magnemite", DexNumbers: [3]uint16{81, 0, 82}}
+	cases := []struct {
+		dex  Pokedex
+		want uint16
+	}{
+		{DexNational, 81}, {DexKanto, 81}, {DexJohto, 0}, {DexHoenn, 82}, {Pokedex(9), 0},
+	}
+	for _, c := range cases {
+		if got := magnemite.DexNumber(c.dex); got != c.want {
+			t.Errorf("Magnemite.DexNumber(%v) = %d, want %d", c.dex, got, c.want)
+		}
+	}
+}
+
+func TestPokedexString_OutOfRange(t *testing.T) {
+	if got := Pokedex(9).String(); got != "Unknown" {
+		t.Errorf("Pokedex(9).String() = %q, want Unknown", got)
+	}
+}
+
+// GrowthRate tests
+
+func TestGrowthRateExpForLevel(t *testing.T) {
+	// Level 100 totals from the games' experience tables.
+	cases := []struct {
+		g    GrowthRate
+		want uint32
+	}{
+		{GrowthErratic, 600000},
+		{GrowthFast, 800000},
+		{GrowthMediumFast, 1000000},
+		{GrowthMediumSlow, 1059860},
+		{GrowthSlow, 1250000},
+		{GrowthFluctuating, 1640000},
+	}
+	for _, c := range cases {
+		if got := c.g.ExpForLevel(100); got != c.want {
+			t.Errorf("GrowthRate(%d).ExpForLevel(100) = %d, want %d", c.g, got, c.want)
+		}
+		if got := c.g.ExpForLevel(1); got != 0 {
+			t.Errorf("GrowthRate(%d).ExpForLevel(1) = %d, want 0", c.g, got)
+		}
+	}
+}
+
+func TestGrowthRateLevelForExp(t *testing.T) {
+	// Medium Slow level 5 needs 135 exp; one point short is still level 4.
+	if got := GrowthMediumSlow.LevelForExp(135); got != 5 {
+		t.Errorf("LevelForExp(135) = %d, want 5", got)
+	}
+	if got := GrowthMediumSlow.LevelForExp(134); got != 4 {
+		t.Errorf("LevelForExp(134) = %d, want 4", got)
+	}
+	if got := GrowthMediumFast.LevelForExp(2000000); got != 100 {
+		t.Errorf("LevelForExp(2000000) = %d, want 100", got)
+	}
+}
+
+// NormalizeName and alias tests
+
+func TestNormalizeName(t *testing.T) {
+	cases := map[string]string{
+		"Mr. Mime":   "mrmime",
+		"mr-mime":    "mrmime",
+		"Nidoran♀":   "nidoranf",
+		"nidoran-f":  "nidoranf",
+		"Farfetch’d": "farfetchd",
+		"Farfetch'd": "farfetchd",
+		"Ho-Oh":      "hooh",
+		"Porygon2":   "porygon2",
+		"Salamèche":  "salameche",
+		"Évoli":      "evoli",
+		"ひとかげ":       "ヒトカゲ",
+		"ヒトカゲ":       "ヒトカゲ",
+		"파이리":        "파이리",
+	}
+	for in, want := range cases {
+		if got := NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBuildAliases(t *testing.T) {
+	mime := &Pokemon{ID: 122, Name: "mr-mime"}
+	deoxys := &Pokemon{ID: 386, Name: "deoxys-normal"}
+	byAlias := buildAliases([]*Pokemon{mime, deoxys}, map[string]string{
+		"Mr. Mime": "mr-mime",
+		"Deoxys":   "deoxys-normal",
+		"Mew":      "mew", // not loaded
+	})
+	for key, want := range map[string]*Pokemon{"mrmime": mime, "deoxys": deoxys, "deoxysnormal": deoxys} {
+		if got := byAlias[key]; got != want {
+			t.Errorf("byAlias[%q] = %v, want %s", key, got, want.Name)
+		}
+	}
+	if _, ok := byAlias["mew"]; ok {
+		t.Error("alias for an unloaded Pokémon should be skipped")
+	}
+}
+
+func TestEvolutionStage(t *testing.T) {
+	saved := ByID
+	t.Cleanup(func() { ByID = saved })
+	charmander := &Pokemon{ID: 4}
+	charmeleon := &Pokemon{ID: 5, EvolvesFrom: 4}
+	charizard := &Pokemon{ID: 6, EvolvesFrom: 5}
+	ByID = map[uint16]*Pokemon{4: charmander, 5: charmeleon, 6: charizard}
+	for p, want := range map[*Pokemon]int{charmander: 1, charmeleon: 2, charizard: 3} {
+		if got := p.EvolutionStage(); got != want {
+			t.Errorf("#%d stage = %d, want %d", p.ID, got, want)
+		}
+	}
+	// A parent missing from ByID still counts as a stage.
+	ByID = map[uint16]*Pokemon{6: charizard}
+	if got := charizard.EvolutionStage(); got != 2 {
+		t.Errorf("stage without the chain loaded = %d, want 2", got)
+	}
+}
+
+func TestObtainableIn(t *testing.T) {
+	saved := ByID
+	t.Cleanup(func() { ByID = saved })
+	zubat := &Pokemon{ID: 41, Locations: []Location{{Game: GameRed}, {Game: GameGold}}}
+	golbat := &Pokemon{ID: 42, EvolvesFrom: 41}
+	crobat := &Pokemon{ID: 169, EvolvesFrom: 42}
+	bulbasaur := &Pokemon{ID: 1} // a gift, so never in the encounter data
+	ByID = map[uint16]*Pokemon{41: zubat, 42: golbat, 169: crobat, 1: bulbasaur}
+	tests := []struct {
+		p    *Pokemon
+		v    GameVersion
+		want bool
+	}{
+		{zubat, GameRed, true},
+		{zubat, GameRuby, false},
+		{golbat, GameRed, true},  // evolved from a wild Zubat
+		{crobat, GameGold, true}, // two steps back
+		{crobat, GameRed, false}, // not in Gen 1 at all
+		{bulbasaur, GameRed, false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.ObtainableIn(tt.v); got != tt.want {
+			t.Errorf("#%d ObtainableIn(%v) = %v, want %v", tt.p.ID, tt.v, got, tt.want)
+		}
+	}
+}
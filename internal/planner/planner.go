@@ -0,0 +1,156 @@
+package planner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// Report compares what can be obtained across two or more game versions.
+type Report struct {
+	Versions []data.GameVersion
+	// Exclusives is parallel to Versions: species catchable in that version only.
+	Exclusives [][]*data.Pokemon
+	// TradeEvolutions need a trade to evolve into, whatever the version.
+	TradeEvolutions []*data.Pokemon
+	// EvolveOnly are not wild in any version but evolve from a species that is.
+	EvolveOnly []*data.Pokemon
+	// NotInWild can't be caught or evolved from a wild species in any version.
+	NotInWild []*data.Pokemon
+}
+
+// Section is a titled group of species, in the order they should be listed.
+type Section struct {
+	Title   string
+	Pokemon []*data.Pokemon
+}
+
+// Build computes the report over the given pokemon (in dex order) for versions.
+// Only species introduced up to the newest selected generation are considered.
+func Build(pokemon []*data.Pokemon, versions []data.GameVersion) Report {
+	r := Report{
+		Versions:   versions,
+		Exclusives: make([][]*data.Pokemon, len(versions)),
+	}
+
+	var maxGen data.Generation
+	for _, v := range versions {
+		if g := data.GenForVersion(v); g > maxGen {
+			maxGen = g
+		}
+	}
+
+	byID := make(map[uint16]*data.Pokemon, len(pokemon))
+	for _, p := range pokemon {
+		byID[p.ID] = p
+	}
+
+	for _, p := range pokemon {
+		if data.IntroducedIn(p.ID) > maxGen {
+			continue
+		}
+		if p.EvolutionTrigger == data.EvolveTrade {
+			r.TradeEvolutions = append(r.TradeEvolutions, p)
+		}
+
+		found := -1
+		count := 0
+		for i, v := range versions {
+			if p.CatchableIn(v) {
+				found = i
+				count++
+			}
+		}
+		switch {
+		case count == 1 && len(versions) > 1:
+			r.Exclusives[found] = append(r.Exclusives[found], p)
+		case count == 0 && wildAncestor(p, byID, versions):
+			r.EvolveOnly = append(r.EvolveOnly, p)
+		case count == 0:
+			r.NotInWild = append(r.NotInWild, p)
+		}
+	}
+	return r
+}
+
+// wildAncestor reports whether any pre-evolution of p is catchable in one of versions.
+func wildAncestor(p *data.Pokemon, byID map[uint16]*data.Pokemon, versions []data.GameVersion) bool {
+	for from := p.EvolvesFrom; from != 0; {
+		pre := byID[from]
+		if pre == nil {
+			return false
+		}
+		for _, v := range versions {
+			if pre.CatchableIn(v) {
+				return true
+			}
+		}
+		from = pre.EvolvesFrom
+	}
+	return false
+}
+
+// Sections returns the report's groups in display order.
+func (r Report) Sections() []Section {
+	var sections []Section
+	for i, v := range r.Versions {
+		sections = append(sections, Section{
+			Title:   v.String() + " only",
+			Pokemon: r.Exclusives[i],
+		})
+	}
+	sections = append(sections,
+		Section{Title: "Needs a trade to evolve", Pokemon: r.TradeEvolutions},
+		Section{Title: "Evolve only (pre-evolution is wild)", Pokemon: r.EvolveOnly},
+		Section{Title: "Not in the wild (gift, trade or event)", Pokemon: r.NotInWild},
+	)
+	return sections
+}
+
+// Title names the compared versions, e.g. "Red vs Blue".
+func (r Report) Title() string {
+	names := make([]string, len(r.Versions))
+	for i, v := range r.Versions {
+		names[i] = v.String()
+	}
+	return strings.Join(names, " vs ")
+}
+
+// WriteChecklist prints the report as a plain-text checklist.
+func (r Report) WriteChecklist(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Completion plan: %s\n", r.Title()); err != nil {
+		return err
+	}
+	for _, s := range r.Sections() {
+		if _, err := fmt.Fprintf(w, "\n%s (%d)\n", s.Title, len(s.Pokemon)); err != nil {
+			return err
+		}
+		for _, p := range s.Pokemon {
+			if _, err := fmt.Fprintf(w, "  [ ] %s\n", Label(p)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Label formats a checklist entry like "#065 Alakazam (Trade)".
+func Label(p *data.Pokemon) string {
+	name := p.Name
+	if name != "" {
+		name = strings.ToUpper(name[:1]) + name[1:]
+	}
+	label := fmt.Sprintf("#%03d %s", p.ID, name)
+	switch p.EvolutionTrigger {
+	case data.EvolveLevelUp:
+		if p.EvolutionLevel > 0 {
+			label += fmt.Sprintf(" (Lv%d)", p.EvolutionLevel)
+		}
+	case data.EvolveNone:
+	default:
+		label += " (" + p.EvolutionTrigger.String() + ")"
+	}
+	return label
+}
@@ -0,0 +1,102 @@
+package planner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+func wild(v data.GameVersion) data.Location {
+	return data.Location{Game: v, AreaName: "route-1"}
+}
+
+// fixture covers each report section for a Red vs Blue comparison.
+var fixture = []*data.Pokemon{
+	{ID: 1, Name: "bulbasaur"}, // starter: not in wild
+	{ID: 2, Name: "ivysaur", EvolvesFrom: 1, EvolutionTrigger: data.EvolveLevelUp, EvolutionLevel: 16},
+	{ID: 23, Name: "ekans", Locations: []data.Location{wild(data.GameRed)}},
+	{ID: 24, Name: "arbok", EvolvesFrom: 23, EvolutionTrigger: data.EvolveLevelUp, EvolutionLevel: 22},
+	{ID: 27, Name: "sandshrew", Locations: []data.Location{wild(data.GameBlue)}},
+	{ID: 63, Name: "abra", Locations: []data.Location{wild(data.GameRed), wild(data.GameBlue)}},
+	{ID: 64, Name: "kadabra", EvolvesFrom: 63, EvolutionTrigger: data.EvolveLevelUp, EvolutionLevel: 16,
+		Locations: []data.Location{wild(data.GameRed), wild(data.GameBlue)}},
+	{ID: 65, Name: "alakazam", EvolvesFrom: 64, EvolutionTrigger: data.EvolveTrade},
+	{ID: 152, Name: "chikorita"}, // Gen 2: out of scope for Red/Blue
+}
+
+func names(ps []*data.Pokemon) []string {
+	out := make([]string, len(ps))
+	for i, p := range ps {
+		out[i] = p.Name
+	}
+	return out
+}
+
+func assertNames(t *testing.T, label string, got []*data.Pokemon, want ...string) {
+	t.Helper()
+	g := names(got)
+	if strings.Join(g, ",") != strings.Join(want, ",") {
+		t.Errorf("%s = %v, want %v", label, g, want)
+	}
+}
+
+func TestBuild_Exclusives(t *testing.T) {
+	r := Build(fixture, []data.GameVersion{data.GameRed, data.GameBlue})
+	assertNames(t, "Red only", r.Exclusives[0], "ekans")
+	assertNames(t, "Blue only", r.Exclusives[1], "sandshrew")
+}
+
+func TestBuild_TradeEvolutions(t *testing.T) {
+	r := Build(fixture, []data.GameVersion{data.GameRed, data.GameBlue})
+	assertNames(t, "TradeEvolutions", r.TradeEvolutions, "alakazam")
+}
+
+func TestBuild_NotInWild(t *testing.T) {
+	r := Build(fixture, []data.GameVersion{data.GameRed, data.GameBlue})
+	assertNames(t, "EvolveOnly", r.EvolveOnly, "arbok", "alakazam")
+	assertNames(t, "NotInWild", r.NotInWild, "bulbasaur", "ivysaur")
+}
+
+func TestBuild_SingleVersionHasNoExclusives(t *testing.T) {
+	r := Build(fixture, []data.GameVersion{data.GameRed})
+	if len(r.Exclusives[0]) != 0 {
+		t.Errorf("single-version exclusives = %v, want none", names(r.Exclusives[0]))
+	}
+	// Sandshrew is Blue-only, so it isn't obtainable in Red.
+	assertNames(t, "NotInWild", r.NotInWild, "bulbasaur", "ivysaur", "sandshrew")
+}
+
+func TestBuild_LaterGenerationIncluded(t *testing.T) {
+	r := Build(fixture, []data.GameVersion{data.GameGold, data.GameSilver})
+	found := false
+	for _, p := range r.NotInWild {
+		if p.Name == "chikorita" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected chikorita in NotInWild for Gold vs Silver")
+	}
+}
+
+func TestWriteChecklist(t *testing.T) {
+	r := Build(fixture, []data.GameVersion{data.GameRed, data.GameBlue})
+	var buf bytes.Buffer
+	if err := r.WriteChecklist(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Completion plan: Red vs Blue",
+		"Red only (1)",
+		"  [ ] #023 Ekans",
+		"  [ ] #065 Alakazam (Trade)",
+		"  [ ] #002 Ivysaur (Lv16)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("checklist missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package save
+
+import "github.com/davidlawson7/pokedex/internal/data"
+
+// Gen 1 (Red/Blue/Yellow) save offsets.
+const (
+	gen1PlayerName  = 0x2598
+	gen1DexOwned    = 0x25A3
+	gen1DexSeen     = 0x25B6
+	gen1TrainerID   = 0x2605
+	gen1CurrentBox  = 0x284C
+	gen1Party       = 0x2F2C
+	gen1BoxData     = 0x30C0 // working copy of the current box
+	gen1Checksum    = 0x3523
+	gen1BoxCount    = 12
+	gen1BoxesInBank = 6
+	gen1BankSize    = 0x1A4C // six boxes, checksummed as one block
+)
+
+var (
+	gen1PartyList = gbList{capacity: 6, structSize: 44}
+	gen1BoxList   = gbList{capacity: 20, structSize: 33}
+)
+
+// gen1Species maps the Gen 1 internal species index to the national dex ID;
+// 0 marks unused (MissingNo.) slots.
+var gen1Species = [191]uint16{
+	0, 112, 115, 32, 35, 21, 100, 34, 80, 2, 103, 108, 102, 88, 94, 29,
+	31, 104, 111, 131, 59, 151, 130, 90, 72, 92, 123, 120, 9, 127, 114, 0,
+	0, 58, 95, 22, 16, 79, 64, 75, 113, 67, 122, 106, 107, 24, 47, 54,
+	96, 76, 0, 126, 0, 125, 82, 109, 0, 56, 86, 50, 128, 0, 0, 0,
+	83, 48, 149, 0, 0, 0, 84, 60, 124, 146, 144, 145, 132, 52, 98, 0,
+	0, 0, 37, 38, 25, 26, 0, 0, 147, 148, 140, 141, 116, 117, 0, 0,
+	27, 28, 138, 139, 39, 40, 133, 136, 135, 134, 66, 41, 23, 46, 61, 62,
+	13, 14, 15, 0, 85, 57, 51, 49, 87, 0, 0, 10, 11, 12, 68, 0,
+	55, 97, 42, 150, 143, 129, 0, 0, 89, 0, 99, 91, 0, 101, 36, 110,
+	53, 105, 0, 93, 63, 65, 17, 18, 121, 1, 3, 73, 0, 118, 119, 0,
+	0, 0, 0, 77, 78, 19, 20, 33, 30, 74, 137, 142, 0, 81, 0, 0,
+	4, 7, 5, 8, 6, 0, 0, 0, 0, 43, 44, 45, 69, 70, 71,
+}
+
+// gen1Sum is the complement of the byte sum over the main data block.
+func gen1Sum(b []byte, from, to int) byte {
+	var sum byte
+	for _, c := range b[from:to] {
+		sum += c
+	}
+	return ^sum
+}
+
+func parseGen1(b []byte) (*Save, bool) {
+	if gen1Sum(b, gen1PlayerName, gen1Checksum) != b[gen1Checksum] {
+		return nil, false
+	}
+	s := &Save{
+		Game:      "Red/Blue/Yellow",
+		Gen:       1,
+		Trainer:   decodeGBText(b[gen1PlayerName : gen1PlayerName+gbNameLen]),
+		TrainerID: be16(b[gen1TrainerID:]),
+		Owned:     dexFlags(b[gen1DexOwned:], 151),
+		Seen:      dexFlags(b[gen1DexSeen:], 151),
+	}
+	s.Party, _ = gen1PartyList.read(b, gen1Party, decodeGen1)
+
+	current := int(b[gen1CurrentBox] & 0x7F)
+	s.Boxes = make([][]Pokemon, gen1BoxCount)
+	for i := 0; i < gen1BoxCount; i++ {
+		if i == current {
+			s.Boxes[i], _ = gen1BoxList.read(b, gen1BoxData, decodeGen1)
+			continue
+		}
+		bank := 0x4000 + (i/gen1BoxesInBank)*0x2000
+		// Banked boxes are only initialized once the player first switches box.
+		if gen1Sum(b, bank, bank+gen1BankSize) != b[bank+gen1BankSize] {
+			continue
+		}
+		off := bank + (i%gen1BoxesInBank)*gen1BoxList.size()
+		s.Boxes[i], _ = gen1BoxList.read(b, off, decodeGen1)
+	}
+	return s, true
+}
+
+// decodeGen1 decodes the 33-byte box struct; party structs add the level at 0x21.
+func decodeGen1(mon []byte) (Pokemon, bool) {
+	if int(mon[0]) >= len(gen1Species) || gen1Species[mon[0]] == 0 {
+		return Pokemon{}, false
+	}
+	p := Pokemon{
+		Species:    gen1Species[mon[0]],
+		Level:      mon[0x03],
+		OTID:       be16(mon[0x0C:]),
+		Experience: be24(mon[0x0E:]),
+		EVs:        statExp(mon[0x11:]),
+		IVs:        dvs(mon[0x1B:]),
+		Gen:        1,
+	}
+	for i := range p.Moves {
+		p.Moves[i] = data.MoveID(mon[0x08+i])
+	}
+	if len(mon) > 0x21 {
+		p.Level = mon[0x21]
+	}
+	return p, true
+}
@@ -0,0 +1,264 @@
+package save
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// ErrChecksum is returned when a file has a save's size but no intact save slot.
+var ErrChecksum = errors.New("no save slot with valid checksums")
+
+// Gen 3 flash layout: two save slots of 14 sections, each a 4 KiB block with
+// a footer holding its section ID, checksum, signature and save counter.
+const (
+	gen3SlotSize      = 0xE000
+	gen3SectionSize   = 0x1000
+	gen3SectionCount  = 14
+	gen3SectionData   = 0xFF4
+	gen3FooterID      = 0xFF4
+	gen3FooterSum     = 0xFF6
+	gen3FooterSig     = 0xFF8
+	gen3FooterIndex   = 0xFFC
+	gen3Signature     = 0x08012025
+	gen3PCFirst       = 5 // sections 5-13 hold the PC buffer
+	gen3BoxCount      = 14
+	gen3BoxSlots      = 30
+	gen3BoxMonSize    = 80
+	gen3PartyMonSize  = 100
+	gen3DexBytes      = 0x31
+	gen3TrainerName   = 0x00
+	gen3TrainerID     = 0x0A
+	gen3GameCode      = 0xAC
+	gen3DexOwned      = 0x28
+	gen3DexSeen       = 0x5C
+	gen3PartyCountRSE = 0x234
+	gen3PartyCountFRL = 0x034
+)
+
+// gen3ChecksumSize is the number of bytes each section's checksum covers.
+var gen3ChecksumSize = [gen3SectionCount]int{
+	3884, 3968, 3968, 3968, 3848, 3968, 3968,
+	3968, 3968, 3968, 3968, 3968, 3968, 2000,
+}
+
+// gen3Species maps internal indexes 277-411 to national dex IDs 252-386; the
+// Hoenn species are stored in a different order. Indexes 1-251 are national IDs.
+var gen3Species = [135]uint16{
+	252, 253, 254, 255, 256, 257, 258, 259, 260, 261, 262, 263, 264, 265, 266,
+	267, 268, 269, 270, 271, 272, 273, 274, 275, 290, 291, 292, 276, 277, 285,
+	286, 327, 278, 279, 283, 284, 320, 321, 300, 301, 352, 343, 344, 299, 324,
+	302, 339, 340, 370, 341, 342, 349, 350, 318, 319, 328, 329, 330, 296, 297,
+	309, 310, 322, 323, 363, 364, 365, 331, 332, 361, 362, 337, 338, 298, 325,
+	326, 311, 312, 303, 307, 308, 333, 334, 360, 355, 356, 315, 287, 288, 289,
+	316, 317, 357, 293, 294, 295, 366, 367, 368, 359, 353, 354, 336, 335, 369,
+	304, 305, 306, 351, 313, 314, 345, 346, 347, 348, 280, 281, 282, 371, 372,
+	373, 374, 375, 376, 377, 378, 379, 382, 383, 384, 380, 381, 385, 386, 358,
+}
+
+const gen3HoennFirstIndex = 277
+
+// gen3NationalID maps a Gen 3 internal species index to its national dex ID, or 0.
+func gen3NationalID(index uint16) uint16 {
+	switch {
+	case index >= 1 && index <= 251:
+		return index
+	case index >= gen3HoennFirstIndex && int(index-gen3HoennFirstIndex) < len(gen3Species):
+		return gen3Species[index-gen3HoennFirstIndex]
+	}
+	return 0
+}
+
+// gen3SubstructOrder gives, for PID % 24, the block position of the Growth,
+// Attacks, EVs and Misc substructures.
+var gen3SubstructOrder = [24][4]int{
+	{0, 1, 2, 3}, {0, 1, 3, 2}, {0, 2, 1, 3}, {0, 3, 1, 2}, {0, 2, 3, 1}, {0, 3, 2, 1},
+	{1, 0, 2, 3}, {1, 0, 3, 2}, {2, 0, 1, 3}, {3, 0, 1, 2}, {2, 0, 3, 1}, {3, 0, 2, 1},
+	{1, 2, 0, 3}, {1, 3, 0, 2}, {2, 1, 0, 3}, {3, 1, 0, 2}, {2, 3, 0, 1}, {3, 2, 0, 1},
+	{1, 2, 3, 0}, {1, 3, 2, 0}, {2, 1, 3, 0}, {3, 1, 2, 0}, {2, 3, 1, 0}, {3, 2, 1, 0},
+}
+
+// gen3Size is the 128 KiB flash; a 64 KiB dump would hold only one slot.
+const gen3Size = 0x20000
+
+func isGBASave(b []byte) bool {
+	return len(b) >= gen3Size && len(b) <= gen3Size+0x100
+}
+
+// gen3SectionSum folds the 32-bit word sum of a section into 16 bits.
+func gen3SectionSum(sec []byte, size int) uint16 {
+	var sum uint32
+	for i := 0; i+4 <= size; i += 4 {
+		sum += binary.LittleEndian.Uint32(sec[i:])
+	}
+	return uint16(sum>>16) + uint16(sum)
+}
+
+// gen3Slot returns the slot's sections indexed by section ID and its save
+// counter, or false if any section is missing or corrupt.
+func gen3Slot(b []byte, base int) ([gen3SectionCount][]byte, uint32, bool) {
+	var secs [gen3SectionCount][]byte
+	var index uint32
+	for i := 0; i < gen3SectionCount; i++ {
+		sec := b[base+i*gen3SectionSize : base+(i+1)*gen3SectionSize]
+		id := int(binary.LittleEndian.Uint16(sec[gen3FooterID:]))
+		if id >= gen3SectionCount || secs[id] != nil {
+			return secs, 0, false
+		}
+		if binary.LittleEndian.Uint32(sec[gen3FooterSig:]) != gen3Signature {
+			return secs, 0, false
+		}
+		if gen3SectionSum(sec, gen3ChecksumSize[id]) != binary.LittleEndian.Uint16(sec[gen3FooterSum:]) {
+			return secs, 0, false
+		}
+		secs[id] = sec
+		index = binary.LittleEndian.Uint32(sec[gen3FooterIndex:])
+	}
+	return secs, index, true
+}
+
+func parseGen3(b []byte) (*Save, error) {
+	secs, idxA, okA := gen3Slot(b, 0)
+	secsB, idxB, okB := gen3Slot(b, gen3SlotSize)
+	switch {
+	case okB && (!okA || idxB > idxA):
+		secs = secsB
+	case !okA:
+		return nil, ErrChecksum
+	}
+
+	trainer := secs[0]
+	s := &Save{
+		Gen:       3,
+		Trainer:   decodeGen3Text(trainer[gen3TrainerName : gen3TrainerName+7]),
+		TrainerID: binary.LittleEndian.Uint16(trainer[gen3TrainerID:]),
+		Owned:     dexFlags(trainer[gen3DexOwned:gen3DexOwned+gen3DexBytes], 386),
+		Seen:      dexFlags(trainer[gen3DexSeen:gen3DexSeen+gen3DexBytes], 386),
+	}
+	partyCount := gen3PartyCountRSE
+	switch binary.LittleEndian.Uint32(trainer[gen3GameCode:]) {
+	case 0:
+		s.Game = "Ruby/Sapphire"
+	case 1:
+		s.Game = "FireRed/LeafGreen"
+		partyCount = gen3PartyCountFRL
+	default:
+		s.Game = "Emerald" // the game code field holds Emerald's security key
+	}
+
+	team := secs[1]
+	count := int(binary.LittleEndian.Uint32(team[partyCount:]))
+	if count > 6 {
+		count = 0
+	}
+	for i := 0; i < count; i++ {
+		off := partyCount + 4 + i*gen3PartyMonSize
+		if p, ok := decodeGen3(team[off : off+gen3PartyMonSize]); ok {
+			s.Party = append(s.Party, p)
+		}
+	}
+
+	var pc []byte
+	for id := gen3PCFirst; id < gen3SectionCount; id++ {
+		pc = append(pc, secs[id][:gen3ChecksumSize[id]]...)
+	}
+	s.Boxes = make([][]Pokemon, gen3BoxCount)
+	for box := 0; box < gen3BoxCount; box++ {
+		for slot := 0; slot < gen3BoxSlots; slot++ {
+			off := 4 + (box*gen3BoxSlots+slot)*gen3BoxMonSize
+			if p, ok := decodeGen3(pc[off : off+gen3BoxMonSize]); ok {
+				s.Boxes[box] = append(s.Boxes[box], p)
+			}
+		}
+	}
+	return s, nil
+}
+
+// decodeGen3 decrypts and unshuffles an 80-byte box or 100-byte party struct.
+// Empty slots and structs failing their checksum report false.
+func decodeGen3(mon []byte) (Pokemon, bool) {
+	pid := binary.LittleEndian.Uint32(mon[0x00:])
+	otid := binary.LittleEndian.Uint32(mon[0x04:])
+	if pid == 0 && otid == 0 {
+		return Pokemon{}, false
+	}
+
+	var plain [48]byte
+	key := pid ^ otid
+	for i := 0; i < 48; i += 4 {
+		binary.LittleEndian.PutUint32(plain[i:], binary.LittleEndian.Uint32(mon[0x20+i:])^key)
+	}
+	return decodeGen3Plain(mon, plain[:], gen3SubstructOrder[pid%24])
+}
+
+// decodeGen3Plain decodes a struct whose 48 data bytes have already been
+// decrypted into plain, with the substructures at the positions in order.
+func decodeGen3Plain(mon, plain []byte, order [4]int) (Pokemon, bool) {
+	var sum uint16
+	for i := 0; i < 48; i += 2 {
+		sum += binary.LittleEndian.Uint16(plain[i:])
+	}
+	if sum != binary.LittleEndian.Uint16(mon[0x1C:]) {
+		return Pokemon{}, false
+	}
+
+	pid := binary.LittleEndian.Uint32(mon[0x00:])
+	otid := binary.LittleEndian.Uint32(mon[0x04:])
+	growth := plain[order[0]*12:]
+	attacks := plain[order[1]*12:]
+	evs := plain[order[2]*12:]
+	misc := plain[order[3]*12:]
+
+	p, ok := gen3FromSubstructs(growth, attacks, evs, misc)
+	if !ok {
+		return Pokemon{}, false
+	}
+	p.PID = pid
+	p.OTID = uint16(otid)
+	p.SecretID = uint16(otid >> 16)
+	p.Nickname = decodeGen3Text(mon[0x08:0x12])
+	p.OTName = decodeGen3Text(mon[0x14:0x1B])
+	if len(mon) >= gen3PartyMonSize {
+		p.Level = mon[0x54]
+	} else if sp := p.Data(); sp != nil {
+		p.Level = sp.GrowthRate.LevelForExp(p.Experience)
+	}
+	return p, true
+}
+
+// gen3FromSubstructs reads the decrypted Growth, Attacks, EVs and Misc blocks.
+func gen3FromSubstructs(growth, attacks, evs, misc []byte) (Pokemon, bool) {
+	species := gen3NationalID(binary.LittleEndian.Uint16(growth[0:]))
+	if species == 0 {
+		return Pokemon{}, false
+	}
+	ivWord := binary.LittleEndian.Uint32(misc[4:])
+	p := Pokemon{
+		Species:    species,
+		Experience: binary.LittleEndian.Uint32(growth[4:]),
+		EVs: Stats{
+			HP:             uint16(evs[0]),
+			Attack:         uint16(evs[1]),
+			Defense:        uint16(evs[2]),
+			Speed:          uint16(evs[3]),
+			SpecialAttack:  uint16(evs[4]),
+			SpecialDefense: uint16(evs[5]),
+		},
+		IVs: Stats{
+			HP:             uint16(ivWord & 0x1F),
+			Attack:         uint16(ivWord >> 5 & 0x1F),
+			Defense:        uint16(ivWord >> 10 & 0x1F),
+			Speed:          uint16(ivWord >> 15 & 0x1F),
+			SpecialAttack:  uint16(ivWord >> 20 & 0x1F),
+			SpecialDefense: uint16(ivWord >> 25 & 0x1F),
+		},
+		IsEgg:       ivWord>>30&1 == 1,
+		AbilitySlot: uint8(ivWord >> 31),
+		Gen:         3,
+	}
+	for i := range p.Moves {
+		p.Moves[i] = data.MoveID(binary.LittleEndian.Uint16(attacks[i*2:]))
+	}
+	return p, true
+}
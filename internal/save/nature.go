@@ -0,0 +1,54 @@
+package save
+
+import "strings"
+
+// Nature is a Gen 3 nature, derived from the PID modulo 25.
+type Nature byte
+
+var natureNames = [25]string{
+	"Hardy", "Lonely", "Brave", "Adamant", "Naughty",
+	"Bold", "Docile", "Relaxed", "Impish", "Lax",
+	"Timid", "Hasty", "Serious", "Jolly", "Naive",
+	"Modest", "Mild", "Quiet", "Bashful", "Rash",
+	"Calm", "Gentle", "Sassy", "Careful", "Quirky",
+}
+
+func (n Nature) String() string { return natureNames[n%25] }
+
+// Stat indexes used by natures, in the games' internal order (HP excluded).
+const (
+	natAttack = iota
+	natDefense
+	natSpeed
+	natSpAttack
+	natSpDefense
+)
+
+// Raised and Lowered return the boosted and hindered stat indexes; they are
+// equal for the five neutral natures.
+func (n Nature) Raised() int  { return int(n%25) / 5 }
+func (n Nature) Lowered() int { return int(n%25) % 5 }
+
+// modifier scales a non-HP stat by 110% or 90% for the nature.
+func (n Nature) modifier(stat int, value uint16) uint16 {
+	up, down := n.Raised(), n.Lowered()
+	switch {
+	case up == down:
+		return value
+	case stat == up:
+		return value * 110 / 100
+	case stat == down:
+		return value * 90 / 100
+	}
+	return value
+}
+
+// ParseNature matches a nature name case-insensitively.
+func ParseNature(name string) (Nature, bool) {
+	for i, n := range natureNames {
+		if strings.EqualFold(n, name) {
+			return Nature(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,60 @@
+package save
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Single-Pokémon files exported by trading tools and PKHeX. The Gen 1-2 files
+// are a one-entry party list; Gen 3 files are a bare box or party struct.
+var (
+	pk1List = gbList{capacity: 1, structSize: 44}
+	pk2List = gbList{capacity: 1, structSize: 48}
+)
+
+// gen3Unshuffled is the Growth/Attacks/EVs/Misc order of decrypted .pk3 files.
+var gen3Unshuffled = [4]int{0, 1, 2, 3}
+
+// ReadPokemon loads a .pk1, .pk2, .pk3 or .ek3 file. The format is chosen by
+// size; .pk3 files are decrypted and .ek3 files are stored as in a save.
+func ReadPokemon(path string) (*Pokemon, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePokemon(b, strings.EqualFold(filepath.Ext(path), ".ek3"))
+}
+
+// ParsePokemon decodes a single-Pokémon file. encrypted selects the .ek3
+// layout for Gen 3 sizes; it is ignored for Gen 1-2 files.
+func ParsePokemon(b []byte, encrypted bool) (*Pokemon, error) {
+	var (
+		p  Pokemon
+		ok bool
+	)
+	switch len(b) {
+	case pk1List.size():
+		p, ok = parsePKList(b, pk1List, decodeGen1)
+	case pk2List.size():
+		p, ok = parsePKList(b, pk2List, decodeGen2)
+	case gen3BoxMonSize, gen3PartyMonSize:
+		if encrypted {
+			p, ok = decodeGen3(b)
+		} else {
+			p, ok = decodeGen3Plain(b, b[0x20:0x50], gen3Unshuffled)
+		}
+	}
+	if !ok {
+		return nil, ErrUnknownFormat
+	}
+	return &p, nil
+}
+
+func parsePKList(b []byte, l gbList, decode func(mon []byte) (Pokemon, bool)) (Pokemon, bool) {
+	mons, ok := l.read(b, 0, decode)
+	if !ok || len(mons) != 1 {
+		return Pokemon{}, false
+	}
+	return mons[0], true
+}
@@ -0,0 +1,202 @@
+package save
+
+import (
+	"errors"
+	"os"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// ErrUnknownFormat is returned when no supported layout's checksum matches.
+var ErrUnknownFormat = errors.New("unrecognized save file format")
+
+// Stats is a six-stat spread. Gen 1-2 have a single Special stat, which is
+// stored in both SpecialAttack and SpecialDefense.
+type Stats struct {
+	HP, Attack, Defense, SpecialAttack, SpecialDefense, Speed uint16
+}
+
+// Pokemon is one individual read from a save file.
+type Pokemon struct {
+	Species    uint16 // national dex ID
+	Nickname   string
+	OTName     string
+	OTID       uint16
+	Level      uint8
+	Experience uint32
+	Moves      [4]data.MoveID
+	IVs        Stats // DVs (0-15) in Gen 1-2
+	EVs        Stats // stat experience (0-65535) in Gen 1-2
+	IsEgg      bool
+	Gen        data.Generation
+
+	// Gen 3 only.
+	PID         uint32
+	SecretID    uint16
+	AbilitySlot uint8 // index into data.Pokemon.Abilities
+}
+
+// Nature returns the Gen 3 nature; it is meaningless for Gen 1-2 Pokémon.
+func (p *Pokemon) Nature() Nature { return Nature(p.PID % 25) }
+
+// Shiny reports whether the Pokémon is shiny. Gen 2 derives it from the DVs and
+// Gen 3 from the PID and trainer IDs; Gen 1 has no shinies, so a Gen 1
+// Pokémon reports what it would become when traded to Gen 2.
+func (p *Pokemon) Shiny() bool {
+	if p.Gen >= 3 {
+		return (p.OTID ^ p.SecretID ^ uint16(p.PID>>16) ^ uint16(p.PID)) < 8
+	}
+	iv := p.IVs
+	return iv.Defense == 10 && iv.Speed == 10 && iv.SpecialAttack == 10 && iv.Attack&2 != 0
+}
+
+// Data returns the species' dex entry, or nil if it isn't loaded.
+func (p *Pokemon) Data() *data.Pokemon { return data.ByID[p.Species] }
+
+// Ability returns the Gen 3 Pokémon's ability. A species with one ability
+// has it whichever slot the PID picks. It is 0 for Gen 1-2 Pokémon and
+// species that aren't loaded.
+func (p *Pokemon) Ability() data.AbilityID { return p.abilityOf(p.Data()) }
+
+// abilityOf is Ability for the given species.
+func (p *Pokemon) abilityOf(sp *data.Pokemon) data.AbilityID {
+	if p.Gen < 3 || sp == nil {
+		return 0
+	}
+	if ab := sp.Abilities[p.AbilitySlot&1]; ab != 0 {
+		return ab
+	}
+	return sp.Abilities[0]
+}
+
+// Save is the read-only contents of a game save.
+type Save struct {
+	Game      string // e.g. "Red/Blue/Yellow", "Crystal"
+	Gen       data.Generation
+	Trainer   string
+	TrainerID uint16
+	Owned     []uint16 // national IDs with the Pokédex owned flag set
+	Seen      []uint16 // national IDs with the Pokédex seen flag set
+	Party     []Pokemon
+	Boxes     [][]Pokemon
+}
+
+// Read loads and parses the save file at path. The file is never written.
+func Read(path string) (*Save, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Parse detects the save's generation by size and checksum and decodes it.
+func Parse(b []byte) (*Save, error) {
+	if isGBASave(b) {
+		return parseGen3(b)
+	}
+	if !isGBSave(b) {
+		return nil, ErrUnknownFormat
+	}
+	if s, ok := parseGen2(b); ok {
+		return s, nil
+	}
+	if s, ok := parseGen1(b); ok {
+		return s, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// gbSaveSize is the 32 KiB battery RAM of the Game Boy carts; emulators may
+// append a small real-time-clock footer.
+const gbSaveSize = 0x8000
+
+func isGBSave(b []byte) bool {
+	return len(b) >= gbSaveSize && len(b) <= gbSaveSize+0x100
+}
+
+// dexFlags returns the national IDs whose bit is set in a little-endian bitfield.
+func dexFlags(b []byte, count int) []uint16 {
+	var ids []uint16
+	for i := 0; i < count; i++ {
+		if b[i/8]&(1<<(i%8)) != 0 {
+			ids = append(ids, uint16(i+1))
+		}
+	}
+	return ids
+}
+
+func be16(b []byte) uint16 { return uint16(b[0])<<8 | uint16(b[1]) }
+
+func be24(b []byte) uint32 { return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2]) }
+
+// dvs unpacks the two Gen 1-2 DV bytes. The HP DV is built from the low bit of
+// each of the other four.
+func dvs(b []byte) Stats {
+	atk := uint16(b[0] >> 4)
+	def := uint16(b[0] & 0xF)
+	spd := uint16(b[1] >> 4)
+	spc := uint16(b[1] & 0xF)
+	hp := (atk&1)<<3 | (def&1)<<2 | (spd&1)<<1 | spc&1
+	return Stats{HP: hp, Attack: atk, Defense: def, SpecialAttack: spc, SpecialDefense: spc, Speed: spd}
+}
+
+// statExp unpacks the five big-endian Gen 1-2 stat experience words.
+func statExp(b []byte) Stats {
+	spc := be16(b[8:])
+	return Stats{
+		HP:             be16(b[0:]),
+		Attack:         be16(b[2:]),
+		Defense:        be16(b[4:]),
+		Speed:          be16(b[6:]),
+		SpecialAttack:  spc,
+		SpecialDefense: spc,
+	}
+}
+
+// gbList describes a Gen 1-2 Pokémon list: a count, a 0xFF-terminated species
+// list, the structs, then OT names and nicknames.
+type gbList struct {
+	capacity   int
+	structSize int
+}
+
+// entry returns the struct, OT name and nickname bytes for slot i of a list at off.
+func (l gbList) entry(b []byte, off, i int) (mon, ot, nick []byte) {
+	structs := off + 1 + l.capacity + 1
+	ots := structs + l.capacity*l.structSize
+	nicks := ots + l.capacity*gbNameLen
+	mon = b[structs+i*l.structSize : structs+(i+1)*l.structSize]
+	ot = b[ots+i*gbNameLen : ots+(i+1)*gbNameLen]
+	nick = b[nicks+i*gbNameLen : nicks+(i+1)*gbNameLen]
+	return mon, ot, nick
+}
+
+// size returns the list's total length in bytes.
+func (l gbList) size() int {
+	return 1 + l.capacity + 1 + l.capacity*(l.structSize+2*gbNameLen)
+}
+
+// read decodes every used slot of the list at off, or reports false if the
+// count is out of range (e.g. an uninitialized box bank).
+func (l gbList) read(b []byte, off int, decode func(mon []byte) (Pokemon, bool)) ([]Pokemon, bool) {
+	count := int(b[off])
+	if count > l.capacity {
+		return nil, false
+	}
+	var out []Pokemon
+	for i := 0; i < count; i++ {
+		mon, ot, nick := l.entry(b, off, i)
+		p, ok := decode(mon)
+		if !ok {
+			continue
+		}
+		p.OTName = decodeGBText(ot)
+		p.Nickname = decodeGBText(nick)
+		if b[off+1+i] == gen2EggMarker {
+			p.IsEgg = true
+		}
+		out = append(out, p)
+	}
+	return out, true
+}
@@ -0,0 +1,498 @@
+package save
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// encodeGBText encodes ASCII letters/digits as Gen 1-2 text, terminated and padded.
+func encodeGBText(s string) []byte {
+	out := make([]byte, gbNameLen)
+	for i := range out {
+		out[i] = gbTerminator
+	}
+	for i, c := range s {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			out[i] = byte(0x80 + c - 'A')
+		case c >= 'a' && c <= 'z':
+			out[i] = byte(0xA0 + c - 'a')
+		case c >= '0' && c <= '9':
+			out[i] = byte(0xF6 + c - '0')
+		}
+	}
+	return out
+}
+
+func setFlag(b []byte, id int) { b[(id-1)/8] |= 1 << ((id - 1) % 8) }
+
+// putList writes a single-entry Pokémon list at off.
+func putList(b []byte, off int, l gbList, speciesByte byte, mon []byte, ot, nick string) {
+	b[off] = 1
+	b[off+1] = speciesByte
+	b[off+2] = 0xFF
+	m, o, n := l.entry(b, off, 0)
+	copy(m, mon)
+	copy(o, encodeGBText(ot))
+	copy(n, encodeGBText(nick))
+}
+
+func buildGen1Save() []byte {
+	b := make([]byte, gbSaveSize)
+	copy(b[gen1PlayerName:], encodeGBText("RED"))
+	b[gen1TrainerID], b[gen1TrainerID+1] = 0x30, 0x39 // 12345
+	setFlag(b[gen1DexOwned:], 25)
+	setFlag(b[gen1DexSeen:], 25)
+	setFlag(b[gen1DexSeen:], 151)
+
+	// Pikachu (internal 0x54), Lv 25, DVs Atk 10 / Def 5 / Spd 9 / Spc 4.
+	mon := make([]byte, 44)
+	mon[0x00] = 0x54
+	mon[0x08], mon[0x09] = 84, 45 // Thunder Shock, Growl
+	mon[0x0C], mon[0x0D] = 0x30, 0x39
+	mon[0x10] = 0x20                  // exp low byte
+	mon[0x11], mon[0x12] = 0x01, 0x00 // HP stat exp 256
+	mon[0x1B], mon[0x1C] = 0xA5, 0x94
+	mon[0x21] = 25
+	putList(b, gen1Party, gen1PartyList, 0x54, mon, "RED", "SPARKY")
+
+	// Current box (box 1): a Mew (0x15) at Lv 5.
+	box := make([]byte, 33)
+	box[0x00] = 0x15
+	box[0x03] = 5
+	putList(b, gen1BoxData, gen1BoxList, 0x15, box, "RED", "MEW")
+
+	b[gen1Checksum] = gen1Sum(b, gen1PlayerName, gen1Checksum)
+	return b
+}
+
+func TestParse_Gen1(t *testing.T) {
+	s, err := Parse(buildGen1Save())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Gen != 1 || s.Trainer != "RED" || s.TrainerID != 12345 {
+		t.Errorf("header = gen %d %q %d, want gen 1 \"RED\" 12345", s.Gen, s.Trainer, s.TrainerID)
+	}
+	if len(s.Owned) != 1 || s.Owned[0] != 25 {
+		t.Errorf("Owned = %v, want [25]", s.Owned)
+	}
+	if len(s.Seen) != 2 || s.Seen[1] != 151 {
+		t.Errorf("Seen = %v, want [25 151]", s.Seen)
+	}
+	if len(s.Party) != 1 {
+		t.Fatalf("Party len = %d, want 1", len(s.Party))
+	}
+	p := s.Party[0]
+	if p.Species != 25 || p.Level != 25 || p.Nickname != "SPARKY" || p.OTName != "RED" {
+		t.Errorf("party[0] = %+v", p)
+	}
+	if p.Moves[0] != 84 || p.Moves[1] != 45 {
+		t.Errorf("Moves = %v, want [84 45 0 0]", p.Moves)
+	}
+	// HP DV = low bits of Atk(0), Def(1), Spd(1), Spc(0) = 0b0110.
+	want := Stats{HP: 6, Attack: 10, Defense: 5, Speed: 9, SpecialAttack: 4, SpecialDefense: 4}
+	if p.IVs != want {
+		t.Errorf("IVs = %+v, want %+v", p.IVs, want)
+	}
+	if p.EVs.HP != 256 {
+		t.Errorf("EVs.HP = %d, want 256", p.EVs.HP)
+	}
+	if len(s.Boxes[0]) != 1 || s.Boxes[0][0].Species != 151 || s.Boxes[0][0].Level != 5 {
+		t.Errorf("box 1 = %+v, want one Lv5 Mew", s.Boxes[0])
+	}
+	// Banked boxes were never initialized, so their checksum fails and they stay empty.
+	if len(s.Boxes[1]) != 0 {
+		t.Errorf("box 2 = %+v, want empty", s.Boxes[1])
+	}
+}
+
+func TestParse_Gen1ChecksumMismatch(t *testing.T) {
+	b := buildGen1Save()
+	b[gen1PlayerName] ^= 0xFF
+	if _, err := Parse(b); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("err = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func buildCrystalSave() []byte {
+	l := gen2Layouts[1]
+	b := make([]byte, gbSaveSize)
+	b[gen2TrainerID], b[gen2TrainerID+1] = 0x00, 0x2A // 42
+	copy(b[gen2PlayerName:], encodeGBText("KRIS"))
+	setFlag(b[l.dexOwned:], 155)
+	setFlag(b[l.dexSeen:], 155)
+	setFlag(b[l.dexSeen:], 251)
+
+	mon := make([]byte, 48)
+	mon[0x00] = 155 // Cyndaquil
+	mon[0x02] = 33  // Tackle
+	mon[0x15], mon[0x16] = 0xFF, 0xFF
+	mon[0x1F] = 14
+	putList(b, l.party, gen2PartyList, 155, mon, "KRIS", "CYNDA")
+
+	// A box in the second bank (box 9), not the current box.
+	box := make([]byte, 32)
+	box[0x00] = 251 // Celebi
+	box[0x1F] = 30
+	putList(b, 0x6000+1*gen2BoxStride, gen2BoxList, 251, box, "KRIS", "CELEBI")
+	// Box 6 is current, so box 1 is read from its bank, which is uninitialized.
+	b[l.currentBox] = 5
+	b[0x4000] = 0xFF
+
+	var sum uint16
+	for _, c := range b[gen2TrainerID:l.checksumEnd] {
+		sum += uint16(c)
+	}
+	b[l.checksum], b[l.checksum+1] = byte(sum), byte(sum>>8)
+	return b
+}
+
+func TestParse_Crystal(t *testing.T) {
+	s, err := Parse(buildCrystalSave())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Game != "Crystal" || s.Gen != 2 || s.Trainer != "KRIS" || s.TrainerID != 42 {
+		t.Errorf("header = %q gen %d %q %d", s.Game, s.Gen, s.Trainer, s.TrainerID)
+	}
+	if len(s.Seen) != 2 || s.Seen[1] != 251 {
+		t.Errorf("Seen = %v, want [155 251]", s.Seen)
+	}
+	if len(s.Party) != 1 || s.Party[0].Species != 155 || s.Party[0].Level != 14 {
+		t.Fatalf("Party = %+v", s.Party)
+	}
+	if s.Party[0].IVs.HP != 15 || s.Party[0].IVs.Speed != 15 {
+		t.Errorf("IVs = %+v, want all 15", s.Party[0].IVs)
+	}
+	if len(s.Boxes[0]) != 0 {
+		t.Errorf("box 1 = %+v, want empty (0xFF count rejected)", s.Boxes[0])
+	}
+	if len(s.Boxes[5]) != 0 {
+		t.Errorf("box 6 = %+v, want empty", s.Boxes[5])
+	}
+	if len(s.Boxes[8]) != 1 || s.Boxes[8][0].Species != 251 || s.Boxes[8][0].Nickname != "CELEBI" {
+		t.Errorf("box 9 = %+v, want one Celebi", s.Boxes[8])
+	}
+}
+
+func TestParse_WrongSize(t *testing.T) {
+	if _, err := Parse(make([]byte, 1024)); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("err = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestGen1SpeciesTable(t *testing.T) {
+	seen := make(map[uint16]bool)
+	for _, id := range gen1Species {
+		if id == 0 {
+			continue
+		}
+		if id > 151 || seen[id] {
+			t.Errorf("gen1Species has invalid or duplicate ID %d", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != 151 {
+		t.Errorf("gen1Species covers %d species, want 151", len(seen))
+	}
+}
+
+// encodeGen3Text encodes ASCII letters as Gen 3 text, terminated and padded to n.
+func encodeGen3Text(s string, n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = gen3Terminator
+	}
+	for i, c := range s {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			out[i] = byte(0xBB + c - 'A')
+		case c >= 'a' && c <= 'z':
+			out[i] = byte(0xD5 + c - 'a')
+		}
+	}
+	return out
+}
+
+// encodeGen3 builds an encrypted Pokémon struct of size 80 or 100.
+func encodeGen3(size int, pid, otid uint32, species uint16, exp uint32, ivWord uint32, evs [6]byte, nick string) []byte {
+	var growth, attacks, evBlock, misc [12]byte
+	binary.LittleEndian.PutUint16(growth[0:], species)
+	binary.LittleEndian.PutUint32(growth[4:], exp)
+	binary.LittleEndian.PutUint16(attacks[0:], 33) // Tackle
+	binary.LittleEndian.PutUint16(attacks[2:], 45) // Growl
+	copy(evBlock[:], evs[:])
+	binary.LittleEndian.PutUint32(misc[4:], ivWord)
+
+	var plain [48]byte
+	order := gen3SubstructOrder[pid%24]
+	copy(plain[order[0]*12:], growth[:])
+	copy(plain[order[1]*12:], attacks[:])
+	copy(plain[order[2]*12:], evBlock[:])
+	copy(plain[order[3]*12:], misc[:])
+
+	mon := make([]byte, size)
+	binary.LittleEndian.PutUint32(mon[0x00:], pid)
+	binary.LittleEndian.PutUint32(mon[0x04:], otid)
+	copy(mon[0x08:], encodeGen3Text(nick, 10))
+	copy(mon[0x14:], encodeGen3Text("MAY", 7))
+	var sum uint16
+	for i := 0; i < 48; i += 2 {
+		sum += binary.LittleEndian.Uint16(plain[i:])
+	}
+	binary.LittleEndian.PutUint16(mon[0x1C:], sum)
+	for i := 0; i < 48; i += 4 {
+		binary.LittleEndian.PutUint32(mon[0x20+i:], binary.LittleEndian.Uint32(plain[i:])^pid^otid)
+	}
+	return mon
+}
+
+// writeGen3Slot lays out the sections of one save slot, rotated by rot, and
+// signs them with the given save counter.
+func writeGen3Slot(b []byte, base, rot int, index uint32, secs [gen3SectionCount][]byte) {
+	for id := 0; id < gen3SectionCount; id++ {
+		sec := b[base+((id+rot)%gen3SectionCount)*gen3SectionSize:][:gen3SectionSize]
+		copy(sec, secs[id])
+		binary.LittleEndian.PutUint16(sec[gen3FooterID:], uint16(id))
+		binary.LittleEndian.PutUint32(sec[gen3FooterSig:], gen3Signature)
+		binary.LittleEndian.PutUint32(sec[gen3FooterIndex:], index)
+		binary.LittleEndian.PutUint16(sec[gen3FooterSum:], gen3SectionSum(sec, gen3ChecksumSize[id]))
+	}
+}
+
+const (
+	testPID  = 0x12345679       // PID % 24 = 1, PID % 25 = 22 (Sassy)
+	testOTID = 54321<<16 | 2468 // SID 54321, TID 2468
+)
+
+func buildGen3Save() []byte {
+	var secs [gen3SectionCount][]byte
+	for i := range secs {
+		secs[i] = make([]byte, gen3SectionData)
+	}
+	trainer := secs[0]
+	copy(trainer[gen3TrainerName:], encodeGen3Text("MAY", 7))
+	binary.LittleEndian.PutUint32(trainer[gen3TrainerID:], testOTID)
+	binary.LittleEndian.PutUint32(trainer[gen3GameCode:], 1) // FireRed/LeafGreen
+	setFlag(trainer[gen3DexOwned:], 252)
+	setFlag(trainer[gen3DexSeen:], 252)
+	setFlag(trainer[gen3DexSeen:], 386)
+
+	// Treecko (internal 277) at Lv 5, all IVs 31 and an egg flag off.
+	party := encodeGen3(gen3PartyMonSize, testPID, testOTID, 277, 135,
+		0x3FFFFFFF|1<<31, [6]byte{4, 8, 0, 12, 0, 0}, "Geckie")
+	party[0x54] = 5
+	binary.LittleEndian.PutUint32(secs[1][gen3PartyCountFRL:], 1)
+	copy(secs[1][gen3PartyCountFRL+4:], party)
+
+	// Box 2 slot 1: a Chikorita (national 152), which straddles no section edge.
+	box := encodeGen3(gen3BoxMonSize, 7, testOTID, 152, 0, 0, [6]byte{}, "Leafy")
+	pc := make([]byte, 0, 9*3968)
+	for id := gen3PCFirst; id < gen3SectionCount; id++ {
+		pc = append(pc, make([]byte, gen3ChecksumSize[id])...)
+	}
+	copy(pc[4+gen3BoxSlots*gen3BoxMonSize:], box)
+	off := 0
+	for id := gen3PCFirst; id < gen3SectionCount; id++ {
+		off += copy(secs[id], pc[off:off+gen3ChecksumSize[id]])
+	}
+
+	b := make([]byte, gen3Size)
+	// Slot B is newer but corrupted below, so slot A must win.
+	writeGen3Slot(b, 0, 3, 10, secs)
+	writeGen3Slot(b, gen3SlotSize, 5, 11, secs)
+	b[gen3SlotSize+gen3SectionSize+0x10] ^= 0xFF
+	return b
+}
+
+func TestParse_Gen3(t *testing.T) {
+	s, err := Parse(buildGen3Save())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Game != "FireRed/LeafGreen" || s.Gen != 3 || s.Trainer != "MAY" || s.TrainerID != 2468 {
+		t.Errorf("header = %q gen %d %q %d", s.Game, s.Gen, s.Trainer, s.TrainerID)
+	}
+	if len(s.Owned) != 1 || s.Owned[0] != 252 || len(s.Seen) != 2 || s.Seen[1] != 386 {
+		t.Errorf("Owned = %v, Seen = %v", s.Owned, s.Seen)
+	}
+	if len(s.Party) != 1 {
+		t.Fatalf("Party len = %d, want 1", len(s.Party))
+	}
+	p := s.Party[0]
+	if p.Species != 252 || p.Level != 5 || p.Nickname != "Geckie" || p.OTName != "MAY" {
+		t.Errorf("party[0] = %+v", p)
+	}
+	if p.SecretID != 54321 || p.AbilitySlot != 1 || p.IsEgg {
+		t.Errorf("SID %d ability %d egg %v, want 54321 1 false", p.SecretID, p.AbilitySlot, p.IsEgg)
+	}
+	if p.Nature().String() != "Sassy" {
+		t.Errorf("Nature = %s, want Sassy", p.Nature())
+	}
+	if p.Moves[0] != 33 || p.Moves[1] != 45 {
+		t.Errorf("Moves = %v, want [33 45 0 0]", p.Moves)
+	}
+	wantIVs := Stats{31, 31, 31, 31, 31, 31}
+	if p.IVs != wantIVs {
+		t.Errorf("IVs = %+v, want all 31", p.IVs)
+	}
+	if p.EVs.Attack != 8 || p.EVs.Speed != 12 {
+		t.Errorf("EVs = %+v, want Atk 8 Spe 12", p.EVs)
+	}
+	if len(s.Boxes[1]) != 1 || s.Boxes[1][0].Species != 152 || s.Boxes[1][0].Nickname != "Leafy" {
+		t.Errorf("box 2 = %+v, want one Chikorita", s.Boxes[1])
+	}
+}
+
+func TestParse_Gen3NoValidSlot(t *testing.T) {
+	b := buildGen3Save()
+	b[0x10] ^= 0xFF // corrupt slot A's first section as well
+	if _, err := Parse(b); !errors.Is(err, ErrChecksum) {
+		t.Errorf("err = %v, want ErrChecksum", err)
+	}
+}
+
+func TestGen3SpeciesTable(t *testing.T) {
+	seen := make(map[uint16]bool)
+	for _, id := range gen3Species {
+		if id < 252 || id > 386 || seen[id] {
+			t.Errorf("gen3Species has invalid or duplicate ID %d", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestActualStats(t *testing.T) {
+	// Garchomp-like spread checked against the published formulas.
+	base := data.BaseStats{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102}
+
+	// Adamant (PID 3): +Atk -SpA; IVs 24/12/30/16/23/5, EVs 74/190/91/48/84/23 at Lv 78.
+	p := Pokemon{Gen: 3, Level: 78, PID: 3,
+		IVs: Stats{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5},
+		EVs: Stats{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23}}
+	want := Stats{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171}
+	if got := p.statsFrom(base); got != want {
+		t.Errorf("Gen 3 stats = %+v, want %+v", got, want)
+	}
+
+	// Gen 1: base 35 HP with DV 6 and 256 stat exp at Lv 25 → ((35+6)*2+4)*25/100+35 = 56.
+	g1 := Pokemon{Gen: 1, Level: 25, IVs: Stats{HP: 6}, EVs: Stats{HP: 256}}
+	if got := g1.statsFrom(data.BaseStats{HP: 35}); got.HP != 56 {
+		t.Errorf("Gen 1 HP = %d, want 56", got.HP)
+	}
+
+	if _, ok := (&Pokemon{Species: 9001, Gen: 3}).ActualStats(); ok {
+		t.Error("ActualStats of an unknown species reported ok")
+	}
+}
+
+func TestAbility(t *testing.T) {
+	single := &data.Pokemon{Abilities: [2]data.AbilityID{26, 0}}
+	double := &data.Pokemon{Abilities: [2]data.AbilityID{65, 34}}
+	tests := []struct {
+		sp   *data.Pokemon
+		gen  data.Generation
+		slot uint8
+		want data.AbilityID
+	}{
+		{double, 3, 0, 65},
+		{double, 3, 1, 34},
+		{single, 3, 1, 26}, // the PID's slot is empty
+		{single, 2, 0, 0},
+		{nil, 3, 0, 0},
+	}
+	for _, tt := range tests {
+		p := Pokemon{Gen: tt.gen, AbilitySlot: tt.slot}
+		if got := p.abilityOf(tt.sp); got != tt.want {
+			t.Errorf("abilityOf(%v) gen %d slot %d = %d, want %d", tt.sp, tt.gen, tt.slot, got, tt.want)
+		}
+	}
+}
+
+func TestParsePokemon_PK1(t *testing.T) {
+	b := make([]byte, pk1List.size())
+	mon := make([]byte, 44)
+	mon[0x00] = 0x54 // Pikachu
+	mon[0x21] = 12
+	// DVs Atk 10 / Def 10 / Spd 10 / Spc 10: the Gen 2 shiny pattern.
+	mon[0x1B], mon[0x1C] = 0xAA, 0xAA
+	putList(b, 0, pk1List, 0x54, mon, "ASH", "PIKA")
+	p, err := ParsePokemon(b, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Species != 25 || p.Level != 12 || p.Nickname != "PIKA" || p.OTName != "ASH" || !p.Shiny() {
+		t.Errorf("pk1 = %+v shiny %v", p, p.Shiny())
+	}
+}
+
+func TestParsePokemon_PK2Egg(t *testing.T) {
+	b := make([]byte, pk2List.size())
+	mon := make([]byte, 48)
+	mon[0x00] = 175 // Togepi
+	mon[0x1F] = 5
+	putList(b, 0, pk2List, 175, mon, "GOLD", "EGG")
+	b[1] = gen2EggMarker
+	p, err := ParsePokemon(b, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Species != 175 || !p.IsEgg || p.Gen != 2 {
+		t.Errorf("pk2 = %+v, want a Togepi egg", p)
+	}
+}
+
+func TestParsePokemon_PK3(t *testing.T) {
+	enc := encodeGen3(gen3PartyMonSize, testPID, testOTID, 277, 135, 0, [6]byte{}, "Geckie")
+	enc[0x54] = 5
+	p, err := ParsePokemon(enc, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Species != 252 || p.Level != 5 {
+		t.Errorf(".ek3 = %+v, want Lv5 Treecko", p)
+	}
+
+	// The decrypted .pk3 layout stores the substructures unshuffled.
+	dec, _ := decodeGen3(enc)
+	plain := make([]byte, gen3PartyMonSize)
+	copy(plain, enc[:0x20])
+	copy(plain[0x54:], enc[0x54:])
+	order := gen3SubstructOrder[testPID%24]
+	key := uint32(testPID ^ testOTID)
+	for sub := 0; sub < 4; sub++ {
+		for i := 0; i < 12; i += 4 {
+			w := binary.LittleEndian.Uint32(enc[0x20+order[sub]*12+i:]) ^ key
+			binary.LittleEndian.PutUint32(plain[0x20+sub*12+i:], w)
+		}
+	}
+	p, err = ParsePokemon(plain, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *p != dec {
+		t.Errorf(".pk3 = %+v, want %+v", *p, dec)
+	}
+}
+
+func TestParsePokemon_UnknownSize(t *testing.T) {
+	if _, err := ParsePokemon(make([]byte, 50), false); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("err = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestShiny_Gen3(t *testing.T) {
+	// TID ^ SID ^ PIDhi ^ PIDlo = 0 ^ 0 ^ 0x0001 ^ 0x0006 = 7 < 8.
+	p := Pokemon{Gen: 3, PID: 0x00010006}
+	if !p.Shiny() {
+		t.Error("expected shiny")
+	}
+	p.OTID = 8
+	if p.Shiny() {
+		t.Error("expected not shiny")
+	}
+}
@@ -0,0 +1,67 @@
+package save
+
+import (
+	"math"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// ActualStats computes the individual's stats at its level from the species'
+// base stats, using the formula of the generation it was read from. It returns
+// false if the species isn't loaded.
+func (p *Pokemon) ActualStats() (Stats, bool) {
+	sp := p.Data()
+	if sp == nil {
+		return Stats{}, false
+	}
+	return p.statsFrom(sp.Stats), true
+}
+
+// statsFrom is ActualStats for the given base stats.
+func (p *Pokemon) statsFrom(b data.BaseStats) Stats {
+	if p.Gen >= 3 {
+		n := p.Nature()
+		return Stats{
+			HP:             gen3HP(b.HP, p.IVs.HP, p.EVs.HP, p.Level),
+			Attack:         n.modifier(natAttack, gen3Stat(b.Attack, p.IVs.Attack, p.EVs.Attack, p.Level)),
+			Defense:        n.modifier(natDefense, gen3Stat(b.Defense, p.IVs.Defense, p.EVs.Defense, p.Level)),
+			SpecialAttack:  n.modifier(natSpAttack, gen3Stat(b.SpecialAttack, p.IVs.SpecialAttack, p.EVs.SpecialAttack, p.Level)),
+			SpecialDefense: n.modifier(natSpDefense, gen3Stat(b.SpecialDefense, p.IVs.SpecialDefense, p.EVs.SpecialDefense, p.Level)),
+			Speed:          n.modifier(natSpeed, gen3Stat(b.Speed, p.IVs.Speed, p.EVs.Speed, p.Level)),
+		}
+	}
+	// Gen 1 has a single Special; Gen 2 split it but shares the DV and stat exp.
+	spDef := b.SpecialDefense
+	if p.Gen == 1 {
+		spDef = b.SpecialAttack
+	}
+	return Stats{
+		HP:             gbStat(b.HP, p.IVs.HP, p.EVs.HP, p.Level) + uint16(p.Level) + 5,
+		Attack:         gbStat(b.Attack, p.IVs.Attack, p.EVs.Attack, p.Level),
+		Defense:        gbStat(b.Defense, p.IVs.Defense, p.EVs.Defense, p.Level),
+		SpecialAttack:  gbStat(b.SpecialAttack, p.IVs.SpecialAttack, p.EVs.SpecialAttack, p.Level),
+		SpecialDefense: gbStat(spDef, p.IVs.SpecialDefense, p.EVs.SpecialDefense, p.Level),
+		Speed:          gbStat(b.Speed, p.IVs.Speed, p.EVs.Speed, p.Level),
+	}
+}
+
+func gen3Core(base uint8, iv, ev uint16, level uint8) uint16 {
+	return (2*uint16(base) + iv + ev/4) * uint16(level) / 100
+}
+
+func gen3HP(base uint8, iv, ev uint16, level uint8) uint16 {
+	if base == 1 { // Shedinja
+		return 1
+	}
+	return gen3Core(base, iv, ev, level) + uint16(level) + 10
+}
+
+func gen3Stat(base uint8, iv, ev uint16, level uint8) uint16 {
+	return gen3Core(base, iv, ev, level) + 5
+}
+
+// gbStat is the Gen 1-2 non-HP formula; HP adds level + 5 on top of it.
+func gbStat(base uint8, dv, statExp uint16, level uint8) uint16 {
+	bonus := uint16(math.Ceil(math.Sqrt(float64(statExp)))) / 4
+	return ((uint16(base)+dv)*2+bonus)*uint16(level)/100 + 5
+}
@@ -0,0 +1,104 @@
+package save
+
+import "strings"
+
+// gbNameLen is the fixed length of Gen 1-2 OT name and nickname fields.
+const gbNameLen = 11
+
+// gbTerminator ends a Gen 1-2 string.
+const gbTerminator = 0x50
+
+// gbChars maps Gen 1-2 English character codes to text; codes not listed decode as '?'.
+var gbChars = func() [256]string {
+	var t [256]string
+	for i := 0; i < 26; i++ {
+		t[0x80+i] = string(rune('A' + i))
+		t[0xA0+i] = string(rune('a' + i))
+	}
+	for i := 0; i < 10; i++ {
+		t[0xF6+i] = string(rune('0' + i))
+	}
+	t[0x7F] = " "
+	t[0x9A] = "("
+	t[0x9B] = ")"
+	t[0x9C] = ":"
+	t[0x9D] = ";"
+	t[0x9E] = "["
+	t[0x9F] = "]"
+	t[0xE0] = "'"
+	t[0xE1] = "PK"
+	t[0xE2] = "MN"
+	t[0xE3] = "-"
+	t[0xE6] = "?"
+	t[0xE7] = "!"
+	t[0xE8] = "."
+	t[0xEF] = "♂"
+	t[0xF1] = "×"
+	t[0xF2] = "."
+	t[0xF3] = "/"
+	t[0xF4] = ","
+	t[0xF5] = "♀"
+	return t
+}()
+
+// decodeGBText decodes a terminated Gen 1-2 string.
+func decodeGBText(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		if c == gbTerminator {
+			break
+		}
+		if s := gbChars[c]; s != "" {
+			sb.WriteString(s)
+		} else {
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// gen3Terminator ends a Gen 3 string.
+const gen3Terminator = 0xFF
+
+// gen3Chars maps Gen 3 English character codes to text; codes not listed decode as '?'.
+var gen3Chars = func() [256]string {
+	var t [256]string
+	for i := 0; i < 26; i++ {
+		t[0xBB+i] = string(rune('A' + i))
+		t[0xD5+i] = string(rune('a' + i))
+	}
+	for i := 0; i < 10; i++ {
+		t[0xA1+i] = string(rune('0' + i))
+	}
+	t[0x00] = " "
+	t[0xAB] = "!"
+	t[0xAC] = "?"
+	t[0xAD] = "."
+	t[0xAE] = "-"
+	t[0xB0] = "…"
+	t[0xB1] = "“"
+	t[0xB2] = "”"
+	t[0xB3] = "‘"
+	t[0xB4] = "’"
+	t[0xB5] = "♂"
+	t[0xB6] = "♀"
+	t[0xB8] = ","
+	t[0xBA] = "/"
+	return t
+}()
+
+// decodeGen3Text decodes a terminated Gen 3 string.
+func decodeGen3Text(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		if c == gen3Terminator {
+			break
+		}
+		if s := gen3Chars[c]; s != "" {
+			sb.WriteString(s)
+		} else {
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
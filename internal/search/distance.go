@@ -0,0 +1,131 @@
+package search
+
+import "strings"
+
+// minTypoQuery is the shortest query matched by edit distance; shorter ones
+// would be within one edit of too many names.
+const minTypoQuery = 4
+
+// maxTypos bounds the edit distance accepted for a query of n runes.
+func maxTypos(n int) int {
+	switch {
+	case n <= 5:
+		return 1
+	case n <= 8:
+		return 2
+	}
+	return 3
+}
+
+// damerauLevenshtein returns the optimal string alignment distance between a
+// and b: insertions, deletions, substitutions and adjacent transpositions.
+func damerauLevenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Three rolling rows: i-2, i-1 and i.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+// typoDistance returns the smallest edit distance between query and either
+// the whole name or a prefix of it about as long as the query, so a typo is
+// forgiven while the rest of the name is still being typed.
+func typoDistance(name, query string) (int, bool) {
+	q := []rune(query)
+	if len(q) < minTypoQuery {
+		return 0, false
+	}
+	limit := maxTypos(len(q))
+	best := damerauLevenshtein(name, query)
+	n := []rune(name)
+	for k := max(len(q)-limit, 1); k <= len(q)+limit && k < len(n); k++ {
+		best = min(best, damerauLevenshtein(string(n[:k]), query))
+	}
+	return best, best <= limit
+}
+
+// soundGroups is a Soundex-style code per letter; 0 letters are vowels or
+// silent and don't produce a code.
+var soundGroups = map[rune]byte{
+	'b': '1', 'f': '1', 'p': '1', 'v': '1',
+	'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+	'd': '3', 't': '3',
+	'l': '4',
+	'm': '5', 'n': '5',
+	'r': '6',
+}
+
+// soundDigraphs are rewritten before coding so common alternative spellings
+// agree, e.g. "ph"/"f" and "qu"/"kw".
+var soundDigraphs = strings.NewReplacer("ph", "f", "qu", "kw", "ck", "k", "gh", "g")
+
+// phoneticKey codes a name by sound. Unlike Soundex the first letter is coded
+// too (so "c" and "k" agree) and the key isn't truncated.
+func phoneticKey(s string) string {
+	s = soundDigraphs.Replace(strings.ToLower(s))
+	var key []byte
+	var last byte
+	for _, r := range s {
+		code, ok := soundGroups[r]
+		switch {
+		case ok && code != last:
+			key = append(key, code)
+			last = code
+		case !ok && r != 'h' && r != 'w':
+			last = 0 // vowels separate repeated codes; h and w don't
+		}
+	}
+	return string(key)
+}
+
+// soundsLike reports whether query sounds like name, or like the start of it
+// once the query codes to at least three sounds.
+func soundsLike(name, query string) bool {
+	qk := phoneticKey(query)
+	if qk == "" {
+		return false
+	}
+	nk := phoneticKey(name)
+	return nk == qk || (len(qk) >= 3 && strings.HasPrefix(nk, qk))
+}
+
+// subsequenceGaps returns how many name runes lie between the first and last
+// matched query runes without being matched, for the leftmost match.
+func subsequenceGaps(name, query string) (int, bool) {
+	q := []rune(query)
+	qi, start, span := 0, -1, 0
+	for i, c := range []rune(name) {
+		if qi < len(q) && q[qi] == c {
+			if start < 0 {
+				start = i
+			}
+			qi++
+			if qi == len(q) {
+				span = i - start + 1
+				break
+			}
+		}
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return span - len(q), true
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+func TestDamerauLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"gengar", "gengar", 0},
+		{"gengar", "gengr", 1}, // deletion
+		{"charizard", "charzard", 1},
+		{"pikachu", "pikahcu", 1}, // transposition
+		{"mew", "mewtwo", 3},
+		{"", "abc", 3},
+	}
+	for _, c := range cases {
+		if got := damerauLevenshtein(c.a, c.b); got != c.want {
+			t.Errorf("damerauLevenshtein(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestPhoneticKey(t *testing.T) {
+	for _, pair := range [][2]string{
+		{"phanpy", "fanpee"},
+		{"charizard", "karizard"},
+		{"quagsire", "kwagsire"},
+	} {
+		if a, b := phoneticKey(pair[0]), phoneticKey(pair[1]); a != b {
+			t.Errorf("phoneticKey(%q) = %q, phoneticKey(%q) = %q; want equal", pair[0], a, pair[1], b)
+		}
+	}
+}
+
+var typoFixture = []*data.Pokemon{
+	{ID: 6, Name: "charizard"},
+	{ID: 39, Name: "jigglypuff"},
+	{ID: 94, Name: "gengar"},
+	{ID: 231, Name: "phanpy"},
+}
+
+func TestFilter_Typos(t *testing.T) {
+	for query, want := range map[string]string{
+		"charzard":  "charizard",
+		"gengr":     "gengar",
+		"jigglypuf": "jigglypuff",
+		"jiglypuff": "jigglypuff",
+		"fanpee":    "phanpy", // sound-alike only
+	} {
+		got := FilterOver(typoFixture, query)
+		if len(got) == 0 || got[0].Name != want {
+			t.Errorf("FilterOver(%q) = %v, want %q first", query, got, want)
+		}
+	}
+}
+
+func TestFilter_TypoTierRanksByDistance(t *testing.T) {
+	pokemon := []*data.Pokemon{
+		{ID: 1, Name: "abcdxy"}, // two edits from the query
+		{ID: 2, Name: "abcdez"}, // one edit
+	}
+	got := FilterOver(pokemon, "abcdef")
+	if len(got) != 2 || got[0].ID != 2 {
+		t.Errorf("FilterOver ranked %v, want the closer name (ID 2) first", got)
+	}
+}
+
+func TestFilter_ShortQueriesSkipTypos(t *testing.T) {
+	if got := FilterOver(typoFixture, "gex"); len(got) != 0 {
+		t.Errorf("FilterOver(\"gex\") = %v, want no typo matches for a 3-letter query", got)
+	}
+}
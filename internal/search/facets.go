@@ -0,0 +1,49 @@
+package search
+
+import "github.com/davidlawson7/pokedex/internal/data"
+
+// MaxStage is the last evolution stage Facets can select.
+const MaxStage = 3
+
+// Facets are checkbox filters, as the search screen's filter panel sets them.
+// Within a facet any checked value matches; facets with nothing checked don't
+// filter, and the rest must all match.
+type Facets struct {
+	Types       [18]bool // indexed by PokeType, checked in the query's generation
+	Gens        [4]bool  // generation of introduction, indexed by Generation
+	CatchableIn [12]bool // has a wild encounter in the GameVersion
+	Stages      [MaxStage + 1]bool
+}
+
+// Empty reports whether nothing is checked.
+func (f Facets) Empty() bool { return f == Facets{} }
+
+// AddFacets adds f's checked facets to q's structured filters.
+func (q *Query) AddFacets(f Facets) {
+	if f.Types != [18]bool{} {
+		q.filters = append(q.filters, func(p *data.Pokemon, _ *Query, gen data.Generation) bool {
+			types := p.TypesForGen(gen)
+			return f.Types[types[0]] || f.Types[types[1]]
+		})
+	}
+	if f.Gens != [4]bool{} {
+		q.filters = append(q.filters, func(p *data.Pokemon, _ *Query, _ data.Generation) bool {
+			return f.Gens[data.IntroducedIn(p.ID)]
+		})
+	}
+	if f.CatchableIn != [12]bool{} {
+		q.filters = append(q.filters, func(p *data.Pokemon, _ *Query, _ data.Generation) bool {
+			for _, loc := range p.Locations {
+				if f.CatchableIn[loc.Game] {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if f.Stages != [MaxStage + 1]bool{} {
+		q.filters = append(q.filters, func(p *data.Pokemon, _ *Query, _ data.Generation) bool {
+			return f.Stages[min(p.EvolutionStage(), MaxStage)]
+		})
+	}
+}
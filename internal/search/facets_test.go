@@ -0,0 +1,62 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+func TestQuery_AddFacets(t *testing.T) {
+	useQueryTables(t)
+	saved := data.ByID
+	t.Cleanup(func() { data.ByID = saved })
+	charmeleon := &data.Pokemon{ID: 5, Name: "charmeleon", EvolvesFrom: 4}
+	data.ByID = map[uint16]*data.Pokemon{5: charmeleon}
+	pokemon := append([]*data.Pokemon{
+		{ID: 6, Name: "charizard", EvolvesFrom: 5, Types: [2]data.PokeType{data.TypeFire, data.TypeFlying},
+			Locations: []data.Location{{Game: data.GameFireRed, AreaName: "route-1"}}},
+	}, queryFixture[1:]...)
+	pokemon = append(pokemon, corpusFixture[3])
+
+	var water, fireOrGhost, gen2, fireRed, stage3, fireStage1 Facets
+	water.Types[data.TypeWater] = true
+	fireOrGhost.Types[data.TypeFire] = true
+	fireOrGhost.Types[data.TypeGhost] = true
+	gen2.Gens[2] = true
+	fireRed.CatchableIn[data.GameFireRed] = true
+	fireRed.CatchableIn[data.GameSilver] = true
+	stage3.Stages[3] = true
+	fireStage1 = fireOrGhost
+	fireStage1.Stages[1] = true
+	if !(Facets{}).Empty() || water.Empty() {
+		t.Error("Empty() should only hold with nothing checked")
+	}
+
+	tests := []struct {
+		facets Facets
+		text   string
+		want   string
+	}{
+		{Facets{}, "", "charizard magnemite gastly lapras typhlosion torchic misdreavus"},
+		{water, "", "lapras"},
+		// This Misdreavus has no types set.
+		{fireOrGhost, "", "charizard gastly typhlosion torchic"},
+		{fireOrGhost, "to", "torchic typhlosion"},
+		{gen2, "", "typhlosion misdreavus"},
+		{fireRed, "", "charizard misdreavus"},
+		// Charmander isn't loaded but still counts, so Charizard is stage 3.
+		{stage3, "", "charizard"},
+		{fireStage1, "", "gastly typhlosion torchic"},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q.AddFacets(tt.facets)
+		if got := strings.Join(names(q.Filter(&Corpus{Pokemon: pokemon})), " "); got != tt.want {
+			t.Errorf("%+v %q = %q, want %q", tt.facets, tt.text, got, tt.want)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package search
 
 import (
-	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/davidlawson7/pokedex/internal/data"
 )
@@ -12,85 +14,234 @@ const (
 	scoreExact      = 100
 	scorePrefix     = 80
 	scoreContains   = 60
+	scoreTypo       = 50
 	scoreSubseq     = 40
+	scorePhonetic   = 20
 	scoreNoMatch    = 0
 )
 
-// filterOver is the pure, testable implementation. It accepts an injected slice.
-func filterOver(pokemon []*data.Pokemon, query string) []*data.Pokemon {
-	if query == "" {
-		result := make([]*data.Pokemon, len(pokemon))
-		copy(result, pokemon)
-		return result
+// Tier is how a name matched the query text, from no match up to exact.
+type Tier byte
+
+const (
+	TierNone        Tier = 0
+	TierPhonetic    Tier = 1
+	TierSubsequence Tier = 2
+	TierTypo        Tier = 3
+	TierContains    Tier = 4
+	TierPrefix      Tier = 5
+	TierExact       Tier = 6
+)
+
+var tierNames = [7]string{"none", "phonetic", "subsequence", "typo", "contains", "prefix", "exact"}
+
+func (t Tier) String() string { return tierNames[t] }
+
+// scoreTiers maps a scoreMatch score to its Tier.
+var scoreTiers = map[int]Tier{
+	scoreExact:    TierExact,
+	scorePrefix:   TierPrefix,
+	scoreContains: TierContains,
+	scoreTypo:     TierTypo,
+	scoreSubseq:   TierSubsequence,
+	scorePhonetic: TierPhonetic,
+}
+
+// scoreMatch computes the match tier for a single name against a query, and
+// the edit distance used to rank within the tier: 0 for exact, prefix and
+// contains matches, the skipped runes for subsequences, and the
+// Damerau-Levenshtein distance for typo and phonetic matches.
+// Both name and query must already be lowercased.
+func scoreMatch(name, query string) (score, dist int) {
+	if name == query {
+		return scoreExact, 0
+	}
+	if strings.HasPrefix(name, query) {
+		return scorePrefix, 0
 	}
+	if strings.Contains(name, query) {
+		return scoreContains, 0
+	}
+	if d, ok := typoDistance(name, query); ok {
+		return scoreTypo, d
+	}
+	if gaps, ok := subsequenceGaps(name, query); ok {
+		return scoreSubseq, gaps
+	}
+	if soundsLike(name, query) {
+		return scorePhonetic, damerauLevenshtein(name, query)
+	}
+	return scoreNoMatch, 0
+}
 
-	q := strings.ToLower(query)
+// nameMatch is how an entry's names matched the query text.
+type nameMatch struct {
+	score     int
+	dist      int
+	positions []int         // rune indices into the name that matched
+	lang      data.Language // which of the entry's names matched
+}
+
+// better reports whether m ranks ahead of o.
+func (m nameMatch) better(o nameMatch) bool {
+	return m.score > o.score || (m.score == o.score && m.dist < o.dist)
+}
+
+// foldedName is a name prepared for matching: lowercased as typed, and
+// normalized (see data.NormalizeName) with the rune each normalized rune
+// came from.
+type foldedName struct {
+	lang  data.Language
+	lower string
+	norm  string
+	index []int // index[i] is the rune in the name that norm's rune i came from
+}
 
-	type scored struct {
-		p     *data.Pokemon
-		score int
-		idx   int
+// foldName prepares a name in lang for matching.
+func foldName(name string, lang data.Language) foldedName {
+	f := foldedName{lang: lang, lower: strings.ToLower(name)}
+	var norm []rune
+	for i, r := range []rune(name) {
+		if c, ok := data.FoldRune(r); ok {
+			norm = append(norm, c)
+			f.index = append(f.index, i)
+		}
 	}
+	f.norm = string(norm)
+	return f
+}
 
-	var matches []scored
-	for i, p := range pokemon {
-		name := strings.ToLower(p.Name)
-		s := scoreMatch(name, q)
-		if s > scoreNoMatch {
-			matches = append(matches, scored{p, s, i})
+// foldNames prepares an entry's display name, then its localized names.
+// The localized English name is skipped: where it differs from the dex name
+// the alias table covers it, and highlights always index the dex name.
+func foldNames(name string, names data.LocalNames) []foldedName {
+	folded := []foldedName{foldName(name, data.LangEnglish)}
+	for lang, n := range names {
+		if n != "" && data.Language(lang) != data.LangEnglish {
+			folded = append(folded, foldName(n, data.Language(lang)))
 		}
 	}
+	return folded
+}
 
-	// Stable sort: higher score first; original index (dex order) as tiebreaker.
-	sort.SliceStable(matches, func(i, j int) bool {
-		if matches[i].score != matches[j].score {
-			return matches[i].score > matches[j].score
+// scorePokemon scores p against a lowercased query. A query like "25" or
+// "#025" matches the national number exactly and regional numbers at the
+// prefix tier. Names also match in any language, with punctuation, spaces,
+// accents and gender symbols normalized away, and through the alias table,
+// so "Mr. Mime", "Nidoran♀" and "ヒトカゲ" all find their Pokémon.
+func scorePokemon(p *data.Pokemon, query string) nameMatch {
+	return matchPokemon(p, foldNames(p.Name, p.Names), query, data.NormalizeName(query))
+}
+
+// matchPokemon is scorePokemon for names already folded by foldNames, with
+// nq the normalized query.
+func matchPokemon(p *data.Pokemon, names []foldedName, query, nq string) nameMatch {
+	if n, ok := dexNumberQuery(query); ok {
+		if int(p.ID) == n {
+			return nameMatch{score: scoreExact}
+		}
+		for _, d := range p.DexNumbers {
+			if int(d) == n {
+				return nameMatch{score: scorePrefix}
+			}
 		}
-		return matches[i].idx < matches[j].idx
-	})
+		return nameMatch{}
+	}
 
-	result := make([]*data.Pokemon, len(matches))
-	for i, m := range matches {
-		result[i] = m.p
+	m := matchFolded(names, query, nq)
+	if nq != "" && m.score < scoreExact && data.ByAlias[nq] == p {
+		m = normalizedMatch(names[0], nq)
+		m.score, m.dist = scoreExact, 0
 	}
-	return result
+	return m
 }
 
-// scoreMatch computes the match score for a single name against a query.
-// Both name and query must already be lowercased.
-func scoreMatch(name, query string) int {
-	if name == query {
-		return scoreExact
+// matchNames scores a lowercased query against name and each of its
+// localized names, returning the best match.
+func matchNames(name string, names data.LocalNames, query string) nameMatch {
+	return matchFolded(foldNames(name, names), query, data.NormalizeName(query))
+}
+
+// matchFolded is matchNames for names already folded by foldNames, with nq
+// the normalized query. The query is matched as typed against the display
+// name, then normalized against every name.
+func matchFolded(names []foldedName, query, nq string) nameMatch {
+	s, d := scoreMatch(names[0].lower, query)
+	best := nameMatch{score: s, dist: d, positions: matchPositions(names[0].lower, query, s)}
+	if nq == "" {
+		return best
 	}
-	if strings.HasPrefix(name, query) {
-		return scorePrefix
+	for _, f := range names {
+		if m := normalizedMatch(f, nq); m.better(best) {
+			best = m
+		}
 	}
-	if strings.Contains(name, query) {
-		return scoreContains
+	return best
+}
+
+// normalizedMatch scores f's normalized form against the normalized query
+// nq and maps the matched positions back onto the name.
+func normalizedMatch(f foldedName, nq string) nameMatch {
+	s, d := scoreMatch(f.norm, nq)
+	var positions []int
+	for _, i := range matchPositions(f.norm, nq, s) {
+		positions = append(positions, f.index[i])
 	}
-	if isSubsequence(name, query) {
-		return scoreSubseq
+	return nameMatch{score: s, dist: d, positions: positions, lang: f.lang}
+}
+
+// dexNumberQuery parses a query made only of digits, optionally after a '#'.
+func dexNumberQuery(query string) (int, bool) {
+	digits := strings.TrimPrefix(query, "#")
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, false
 	}
-	return scoreNoMatch
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
 }
 
-// isSubsequence returns true if query is a subsequence of name.
-func isSubsequence(name, query string) bool {
-	qi := 0
-	for _, c := range name {
-		if qi < len(query) && rune(query[qi]) == c {
-			qi++
+// matchPositions returns the rune indices of name that matched query at the
+// given score, for highlighting. Typo and phonetic matches highlight the
+// query runes found in order, skipping any the name doesn't have.
+// Both name and query must already be lowercased.
+func matchPositions(name, query string, score int) []int {
+	n := utf8.RuneCountInString(query)
+	switch score {
+	case scoreNoMatch:
+		return nil
+	case scoreExact, scorePrefix, scoreContains:
+		start := utf8.RuneCountInString(name[:strings.Index(name, query)])
+		pos := make([]int, n)
+		for i := range pos {
+			pos[i] = start + i
 		}
+		return pos
 	}
-	return qi == len(query)
+	nr, qr := []rune(name), []rune(query)
+	var pos []int
+	ni := 0
+	for _, c := range qr {
+		for j := ni; j < len(nr); j++ {
+			if nr[j] == c {
+				pos = append(pos, j)
+				ni = j + 1
+				break
+			}
+		}
+	}
+	return pos
 }
 
-// Filter is the public API, wrapping filterOver with data.AllPokemon.
+// pokedex is every species, indexed once for Filter.
+var pokedex = sync.OnceValue(func() *Corpus { return &Corpus{Pokemon: data.AllPokemon} })
+
+// Filter ranks every species by query.
 func Filter(query string) []*data.Pokemon {
-	return filterOver(data.AllPokemon, query)
+	return (&Query{Text: query}).Filter(pokedex())
 }
 
-// FilterOver is the exported version of filterOver for use by the TUI package.
+// FilterOver ranks pokemon by query. It indexes pokemon on every call; keep
+// a Corpus to filter the same species repeatedly.
 func FilterOver(pokemon []*data.Pokemon, query string) []*data.Pokemon {
-	return filterOver(pokemon, query)
+	return (&Query{Text: query}).Filter(&Corpus{Pokemon: pokemon})
 }
@@ -19,9 +19,9 @@ var fixture = []*data.Pokemon{
 }
 
 func TestFilter_EmptyQuery_ReturnsAll(t *testing.T) {
-	got := filterOver(fixture, "")
+	got := FilterOver(fixture, "")
 	if len(got) != len(fixture) {
-		t.Errorf("filterOver(fixture, \"\") len = %d, want %d", len(got), len(fixture))
+		t.Errorf("FilterOver(fixture, \"\") len = %d, want %d", len(got), len(fixture))
 	}
 	// Should be in dex order (original slice order)
 	for i, p := range got {
@@ -32,7 +32,7 @@ func TestFilter_EmptyQuery_ReturnsAll(t *testing.T) {
 }
 
 func TestFilter_ExactMatch_ScoresHighest(t *testing.T) {
-	got := filterOver(fixture, "rattata")
+	got := FilterOver(fixture, "rattata")
 	if len(got) == 0 {
 		t.Fatal("expected at least one result")
 	}
@@ -42,7 +42,7 @@ func TestFilter_ExactMatch_ScoresHighest(t *testing.T) {
 }
 
 func TestFilter_PrefixMatch(t *testing.T) {
-	got := filterOver(fixture, "char")
+	got := FilterOver(fixture, "char")
 	if len(got) < 3 {
 		t.Fatalf("expected at least 3 results for \"char\", got %d", len(got))
 	}
@@ -72,7 +72,7 @@ func TestFilter_PrefixMatch(t *testing.T) {
 }
 
 func TestFilter_ContainsMatch(t *testing.T) {
-	got := filterOver(fixture, "saur")
+	got := FilterOver(fixture, "saur")
 	names := make(map[string]bool, len(got))
 	for _, p := range got {
 		names[p.Name] = true
@@ -85,7 +85,7 @@ func TestFilter_ContainsMatch(t *testing.T) {
 }
 
 func TestFilter_SubsequenceMatch(t *testing.T) {
-	got := filterOver(fixture, "bsr")
+	got := FilterOver(fixture, "bsr")
 	found := false
 	for _, p := range got {
 		if p.Name == "bulbasaur" {
@@ -99,14 +99,14 @@ func TestFilter_SubsequenceMatch(t *testing.T) {
 }
 
 func TestFilter_NoMatch_ReturnsEmpty(t *testing.T) {
-	got := filterOver(fixture, "zzz")
+	got := FilterOver(fixture, "zzz")
 	if len(got) != 0 {
 		t.Errorf("expected empty results for \"zzz\", got %d", len(got))
 	}
 }
 
 func TestFilter_CaseInsensitive(t *testing.T) {
-	got := filterOver(fixture, "CHAR")
+	got := FilterOver(fixture, "CHAR")
 	if len(got) == 0 {
 		t.Error("expected results for \"CHAR\", got none")
 	}
@@ -117,7 +117,7 @@ func TestFilter_CaseInsensitive(t *testing.T) {
 
 func TestFilter_ScoreOrdering(t *testing.T) {
 	// "charmander" is exact, "char" prefix → charmander before charmeleon before charizard
-	got := filterOver(fixture, "charmander")
+	got := FilterOver(fixture, "charmander")
 	if len(got) == 0 {
 		t.Fatal("no results")
 	}
@@ -127,9 +127,64 @@ func TestFilter_ScoreOrdering(t *testing.T) {
 }
 
 func TestFilter_PublicWrapper(t *testing.T) {
-	// Filter wraps filterOver with data.AllPokemon; smoke test that it returns a slice
+	// Filter ranks data.AllPokemon; smoke test that it returns a slice
 	// (AllPokemon is nil at test time since generated init hasn't run, returns empty)
 	got := Filter("")
 	// Just verify it doesn't panic
 	_ = got
 }
+
+var aliasFixture = []*data.Pokemon{
+	{ID: 25, Name: "pikachu", DexNumbers: [3]uint16{25, 22, 156}},
+	{ID: 29, Name: "nidoran-f", DexNumbers: [3]uint16{29, 0, 0}},
+	{ID: 83, Name: "farfetchd", DexNumbers: [3]uint16{83, 0, 0}},
+	{ID: 122, Name: "mr-mime", DexNumbers: [3]uint16{122, 0, 0}},
+	{ID: 172, Name: "pichu", DexNumbers: [3]uint16{0, 21, 155}},
+	{ID: 179, Name: "mareep", DexNumbers: [3]uint16{0, 53, 25}},
+}
+
+func TestFilter_DexNumbers(t *testing.T) {
+	for _, query := range []string{"25", "#025", "#25", "pikachu"} {
+		got := FilterOver(aliasFixture, query)
+		if len(got) == 0 || got[0].Name != "pikachu" {
+			t.Errorf("FilterOver(%q) = %v, want pikachu first", query, got)
+		}
+	}
+	// Hoenn #25 is Mareep; the national number still ranks first.
+	got := FilterOver(aliasFixture, "25")
+	if len(got) != 2 || got[1].Name != "mareep" {
+		t.Errorf("FilterOver(\"25\") = %v, want pikachu then mareep", got)
+	}
+	if got := FilterOver(aliasFixture, "999"); len(got) != 0 {
+		t.Errorf("FilterOver(\"999\") = %v, want none", got)
+	}
+}
+
+func TestFilter_NormalizedNames(t *testing.T) {
+	for query, want := range map[string]string{
+		"Mr. Mime":   "mr-mime",
+		"mr mime":    "mr-mime",
+		"mrmime":     "mr-mime",
+		"Nidoran♀":   "nidoran-f",
+		"Farfetch'd": "farfetchd",
+		"farfetch’d": "farfetchd",
+	} {
+		got := FilterOver(aliasFixture, query)
+		if len(got) == 0 || got[0].Name != want {
+			t.Errorf("FilterOver(%q) = %v, want %s first", query, got, want)
+		}
+	}
+}
+
+func TestScorePokemon_Alias(t *testing.T) {
+	deoxys := &data.Pokemon{ID: 386, Name: "deoxys-normal"}
+	if s := scorePokemon(deoxys, "deoxys").score; s != scorePrefix {
+		t.Fatalf("without an alias score = %d, want prefix", s)
+	}
+	saved := data.ByAlias
+	t.Cleanup(func() { data.ByAlias = saved })
+	data.ByAlias = map[string]*data.Pokemon{"deoxys": deoxys}
+	if s := scorePokemon(deoxys, "deoxys").score; s != scoreExact {
+		t.Errorf("with an alias score = %d, want exact", s)
+	}
+}
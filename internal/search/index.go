@@ -0,0 +1,422 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// Index is a Corpus prepared for searching: every name is lowercased and
+// normalized once, and trigram, phonetic and dex number tables narrow each
+// query down to the entries that can match before any scoring is done.
+// An Index is read-only once built; use a Searcher to query it.
+type Index struct {
+	corpus  *Corpus
+	entries []indexEntry
+	// trigrams maps each trigram of any lowercased or normalized name to the
+	// entries that contain it, in ascending order.
+	trigrams map[string][]int32
+	// phonetic holds every name's phoneticKey, sorted by key, so equal keys
+	// and key prefixes are contiguous ranges.
+	phonetic []phoneticEntry
+	// numbers maps national and regional dex numbers to Pokémon entries.
+	numbers map[int][]int32
+	// byPokemon finds a Pokémon's entry, for alias hits.
+	byPokemon map[*data.Pokemon]int32
+}
+
+// indexEntry is one searchable Pokémon, move, ability or location.
+type indexEntry struct {
+	result   Result // Kind and the matching pointer field only
+	names    []foldedName
+	maxRunes int // longest lowercased or normalized name, in runes
+	// letters holds each name's runes in sorted order: the lowercased
+	// display name first, then each normalized name.
+	letters [][]rune
+}
+
+type phoneticEntry struct {
+	key string
+	id  int32
+}
+
+// NewIndex builds the index for c. Entries are ordered Pokémon first, in
+// the corpus order, then moves, abilities and locations, which is the order
+// Search breaks ties in.
+func NewIndex(c *Corpus) *Index {
+	ix := &Index{
+		corpus:    c,
+		trigrams:  make(map[string][]int32),
+		numbers:   make(map[int][]int32),
+		byPokemon: make(map[*data.Pokemon]int32, len(c.Pokemon)),
+	}
+	for _, p := range c.Pokemon {
+		id := ix.add(Result{Kind: KindPokemon, Pokemon: p}, foldNames(p.Name, p.Names))
+		ix.byPokemon[p] = id
+		ix.numbers[int(p.ID)] = append(ix.numbers[int(p.ID)], id)
+		for _, n := range p.DexNumbers {
+			if n != 0 && n != p.ID {
+				ix.numbers[int(n)] = append(ix.numbers[int(n)], id)
+			}
+		}
+	}
+	for _, m := range c.Moves {
+		r := Result{Kind: KindMove, Move: m}
+		ix.add(r, foldNames(r.Name(), m.Names))
+	}
+	for _, a := range c.Abilities {
+		r := Result{Kind: KindAbility, Ability: a}
+		ix.add(r, foldNames(r.Name(), a.Names))
+	}
+	for _, a := range c.Areas {
+		r := Result{Kind: KindLocation, Area: a}
+		ix.add(r, foldNames(r.Name(), data.LocalNames{}))
+	}
+	sort.Slice(ix.phonetic, func(i, j int) bool {
+		if ix.phonetic[i].key != ix.phonetic[j].key {
+			return ix.phonetic[i].key < ix.phonetic[j].key
+		}
+		return ix.phonetic[i].id < ix.phonetic[j].id
+	})
+	return ix
+}
+
+// Corpus returns the corpus the index was built from.
+func (ix *Index) Corpus() *Corpus { return ix.corpus }
+
+func (ix *Index) add(r Result, names []foldedName) int32 {
+	id := int32(len(ix.entries))
+	e := indexEntry{result: r, names: names}
+	keys := []string{names[0].lower}
+	for _, f := range names {
+		keys = append(keys, f.norm)
+	}
+	for _, k := range keys {
+		e.letters = append(e.letters, sortedRunes(k))
+		e.maxRunes = max(e.maxRunes, utf8.RuneCountInString(k))
+		for _, g := range trigramsOf(k) {
+			if posts := ix.trigrams[g]; len(posts) == 0 || posts[len(posts)-1] != id {
+				ix.trigrams[g] = append(posts, id)
+			}
+		}
+		if pk := phoneticKey(k); pk != "" {
+			ix.phonetic = append(ix.phonetic, phoneticEntry{pk, id})
+		}
+	}
+	ix.entries = append(ix.entries, e)
+	return id
+}
+
+// trigramsOf returns the distinct rune trigrams of s.
+func trigramsOf(s string) []string {
+	r := []rune(s)
+	var out []string
+	seen := make(map[string]bool)
+	for i := 0; i+3 <= len(r); i++ {
+		if g := string(r[i : i+3]); !seen[g] {
+			seen[g] = true
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// match scores entry e against the lowercased query and its normalized form.
+func (ix *Index) match(e *indexEntry, query, nq string) nameMatch {
+	if e.result.Kind == KindPokemon {
+		return matchPokemon(e.result.Pokemon, e.names, query, nq)
+	}
+	return matchFolded(e.names, query, nq)
+}
+
+// Searcher runs queries against an Index. It remembers the entries the last
+// query's text was a subsequence of, so when the next query extends it, as
+// it does while typing, only those entries are checked again.
+type Searcher struct {
+	ix *Index
+	// lastNQ is the normalized text of the last query, and lastSubseq the
+	// entries with a name it's a subsequence of.
+	lastNQ     string
+	lastSubseq []int32
+	// mark is scratch space for collecting candidates without duplicates.
+	mark []bool
+}
+
+// NewSearcher returns a Searcher over ix.
+func NewSearcher(ix *Index) *Searcher {
+	return &Searcher{ix: ix, mark: make([]bool, len(ix.entries))}
+}
+
+// Index returns the index s searches.
+func (s *Searcher) Index() *Index { return s.ix }
+
+// Search returns typed results ranked by score, as Query.Search does for the
+// index's corpus.
+func (s *Searcher) Search(q *Query) []Result {
+	ix := s.ix
+	text := strings.ToLower(q.Text)
+	if text == "" {
+		var results []Result
+		for _, p := range ix.corpus.Pokemon {
+			if q.Match(p) {
+				results = append(results, Result{Kind: KindPokemon, Pokemon: p})
+			}
+		}
+		return results
+	}
+	others := len(q.filters) == 0 && utf8.RuneCountInString(text) >= minOtherKindQuery
+
+	nq := data.NormalizeName(text)
+	var results []Result
+	for _, id := range s.candidates(text, nq) {
+		e := &ix.entries[id]
+		if e.result.Kind != KindPokemon && !others {
+			continue
+		}
+		if e.result.Kind == KindPokemon && !q.Match(e.result.Pokemon) {
+			continue
+		}
+		r := e.result
+		if r.setMatch(ix.match(e, text, nq)) {
+			results = append(results, r)
+		}
+	}
+	sortResults(results)
+	return results
+}
+
+// Filter matches text against the names of the index's entries of one
+// kind, in every language, ranked as Search ranks them and otherwise in
+// corpus order. Unlike Search it matches moves, abilities and locations
+// however short the text is. Empty text returns every entry of the kind.
+func (s *Searcher) Filter(kind Kind, text string) []Result {
+	ix := s.ix
+	text = strings.ToLower(text)
+	var results []Result
+	if text == "" {
+		for i := range ix.entries {
+			if ix.entries[i].result.Kind == kind {
+				results = append(results, ix.entries[i].result)
+			}
+		}
+		return results
+	}
+	nq := data.NormalizeName(text)
+	for _, id := range s.candidates(text, nq) {
+		e := &ix.entries[id]
+		if e.result.Kind != kind {
+			continue
+		}
+		r := e.result
+		if r.setMatch(ix.match(e, text, nq)) {
+			results = append(results, r)
+		}
+	}
+	sortResults(results)
+	return results
+}
+
+// candidates returns, in entry order, every entry that can match the
+// lowercased query text or its normalized form nq at any tier:
+//
+//   - exact, prefix, contains and subsequence matches all need nq to be a
+//     subsequence of a normalized name, which only narrows as text is typed;
+//   - a typo match is bounded by shared trigrams and runes, see
+//     typoCandidates;
+//   - a phonetic match needs the name's key to equal or extend the query's;
+//   - dex numbers and aliases are looked up directly.
+func (s *Searcher) candidates(text, nq string) []int32 {
+	ix := s.ix
+	if nq == "" {
+		// Only punctuation, which normalizes away; nothing to narrow by.
+		all := make([]int32, len(ix.entries))
+		for i := range all {
+			all[i] = int32(i)
+		}
+		s.lastNQ, s.lastSubseq = "", nil
+		return all
+	}
+
+	var found []int32
+	add := func(id int32) {
+		if !s.mark[id] {
+			s.mark[id] = true
+			found = append(found, id)
+		}
+	}
+
+	// Subsequence candidates, narrowed from the last query when it's a prefix.
+	var subseq []int32
+	if s.lastNQ != "" && strings.HasPrefix(nq, s.lastNQ) {
+		for _, id := range s.lastSubseq {
+			if ix.entries[id].hasSubsequence(nq) {
+				subseq = append(subseq, id)
+			}
+		}
+	} else {
+		for i := range ix.entries {
+			if ix.entries[i].hasSubsequence(nq) {
+				subseq = append(subseq, int32(i))
+			}
+		}
+	}
+	s.lastNQ, s.lastSubseq = nq, subseq
+	for _, id := range subseq {
+		add(id)
+	}
+
+	// The query as typed is only typo-matched against the display name; the
+	// normalized query against every normalized name.
+	s.typoCandidates(text, true, add)
+	s.typoCandidates(nq, false, add)
+	for _, q := range []string{text, nq} {
+		s.phoneticCandidates(q, add)
+	}
+	if n, ok := dexNumberQuery(text); ok {
+		for _, id := range ix.numbers[n] {
+			add(id)
+		}
+	}
+	if p := data.ByAlias[nq]; p != nil {
+		if id, ok := ix.byPokemon[p]; ok {
+			add(id)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i] < found[j] })
+	for _, id := range found {
+		s.mark[id] = false
+	}
+	return found
+}
+
+// typoCandidates adds the entries q could be a typo of, checking only the
+// display name when display is set and only the normalized names otherwise.
+//
+// Within k edits, q keeps all but at most 4k of its trigrams, and at most k
+// of its runes are missing from the name: each edit supplies one rune at
+// most. Both bounds hold for a prefix of the name too, since it has no
+// trigrams or runes the whole name lacks.
+func (s *Searcher) typoCandidates(q string, display bool, add func(int32)) {
+	n := utf8.RuneCountInString(q)
+	if n < minTypoQuery {
+		return
+	}
+	limit := maxTypos(n)
+	qletters := sortedRunes(q)
+	check := func(id int32) {
+		e := &s.ix.entries[id]
+		if e.maxRunes < n-limit {
+			return
+		}
+		letters := e.letters[1:]
+		if display {
+			letters = e.letters[:1]
+		}
+		for _, l := range letters {
+			if missingRunes(qletters, l) <= limit {
+				add(id)
+				return
+			}
+		}
+	}
+
+	grams := trigramsOf(q)
+	need := len(grams) - 4*limit
+	if need <= 0 {
+		// Too short for the trigram bound to rule anything out.
+		for i := range s.ix.entries {
+			check(int32(i))
+		}
+		return
+	}
+	counts := make(map[int32]int)
+	for _, g := range grams {
+		for _, id := range s.ix.trigrams[g] {
+			counts[id]++
+		}
+	}
+	for id, c := range counts {
+		if c >= need {
+			check(id)
+		}
+	}
+}
+
+// sortedRunes returns the runes of s in ascending order.
+func sortedRunes(s string) []rune {
+	r := []rune(s)
+	sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+	return r
+}
+
+// missingRunes counts the runes of q, as a multiset, that name lacks. Both
+// must be sorted.
+func missingRunes(q, name []rune) int {
+	missing, j := 0, 0
+	for _, c := range q {
+		for j < len(name) && name[j] < c {
+			j++
+		}
+		if j < len(name) && name[j] == c {
+			j++
+		} else {
+			missing++
+		}
+	}
+	return missing
+}
+
+// phoneticCandidates adds the entries whose key soundsLike accepts for q.
+func (s *Searcher) phoneticCandidates(q string, add func(int32)) {
+	qk := phoneticKey(q)
+	if qk == "" {
+		return
+	}
+	ph := s.ix.phonetic
+	i := sort.Search(len(ph), func(i int) bool { return ph[i].key >= qk })
+	for ; i < len(ph); i++ {
+		if ph[i].key != qk && (len(qk) < 3 || !strings.HasPrefix(ph[i].key, qk)) {
+			break
+		}
+		add(ph[i].id)
+	}
+}
+
+// hasSubsequence reports whether nq is a subsequence of any of e's
+// normalized names.
+func (e *indexEntry) hasSubsequence(nq string) bool {
+	for _, f := range e.names {
+		if isSubsequence(f.norm, nq) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSubsequence reports whether every rune of sub appears in s in order.
+func isSubsequence(s, sub string) bool {
+	for _, c := range s {
+		if sub == "" {
+			return true
+		}
+		r, size := utf8.DecodeRuneInString(sub)
+		if c == r {
+			sub = sub[size:]
+		}
+	}
+	return sub == ""
+}
+
+// sortResults orders results by score, then distance within the tier;
+// entries that tie keep their order.
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].dist < results[j].dist
+	})
+}
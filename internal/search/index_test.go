@@ -0,0 +1,262 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// scanFilter is Query.Filter without an index: the structured filters are
+// tested and the free text scored against every species.
+func scanFilter(q *Query, pokemon []*data.Pokemon) []*data.Pokemon {
+	var kept []*data.Pokemon
+	for _, p := range pokemon {
+		if q.Match(p) {
+			kept = append(kept, p)
+		}
+	}
+	return scanText(kept, q.Text)
+}
+
+// scanText ranks pokemon by query, keeping dex order within a tier.
+func scanText(pokemon []*data.Pokemon, query string) []*data.Pokemon {
+	if query == "" {
+		result := make([]*data.Pokemon, len(pokemon))
+		copy(result, pokemon)
+		return result
+	}
+
+	q := strings.ToLower(query)
+
+	type scored struct {
+		p     *data.Pokemon
+		score int
+		dist  int
+		idx   int
+	}
+
+	var matches []scored
+	for i, p := range pokemon {
+		if m := scorePokemon(p, q); m.score > scoreNoMatch {
+			matches = append(matches, scored{p, m.score, m.dist, i})
+		}
+	}
+
+	// Stable sort: higher score first, then smaller distance within the tier;
+	// original index (dex order) as tiebreaker.
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].idx < matches[j].idx
+	})
+
+	result := make([]*data.Pokemon, len(matches))
+	for i, m := range matches {
+		result[i] = m.p
+	}
+	return result
+}
+
+// scanSearch is Query.Search without an index: every name is scored against
+// the query. The index must return exactly what it does.
+func scanSearch(q *Query, c *Corpus) []Result {
+	var results []Result
+	for _, p := range scanFilter(q, c.Pokemon) {
+		results = append(results, Result{Kind: KindPokemon, Pokemon: p})
+	}
+	text := strings.ToLower(q.Text)
+	if text != "" {
+		for i := range results {
+			results[i].setMatch(scorePokemon(results[i].Pokemon, text))
+		}
+	}
+	if len(q.filters) > 0 || len([]rune(text)) < minOtherKindQuery {
+		return results
+	}
+	add := func(r Result) {
+		if r.setMatch(matchNames(r.Name(), r.Names(), text)) {
+			results = append(results, r)
+		}
+	}
+	for _, m := range c.Moves {
+		add(Result{Kind: KindMove, Move: m})
+	}
+	for _, a := range c.Abilities {
+		add(Result{Kind: KindAbility, Ability: a})
+	}
+	for _, a := range c.Areas {
+		add(Result{Kind: KindLocation, Area: a})
+	}
+	sortResults(results)
+	return results
+}
+
+func indexFixture() []*data.Pokemon {
+	var ps []*data.Pokemon
+	for _, f := range [][]*data.Pokemon{queryFixture, corpusFixture, aliasFixture, typoFixture} {
+		for _, p := range f {
+			cp := *p
+			ps = append(ps, &cp)
+		}
+	}
+	ps[0].Names = data.LocalNames{data.LangJapanese: "リザードン", data.LangFrench: "Dracaufeu"}
+	return ps
+}
+
+func TestSearcher_MatchesScan(t *testing.T) {
+	useQueryTables(t)
+	data.AllMoves[33] = &data.Move{ID: 33, Name: "tackle",
+		Names: data.LocalNames{data.LangFrench: "Charge", data.LangGerman: "Tackle"}}
+	c := NewCorpus(indexFixture())
+	s := NewSearcher(NewIndex(c))
+
+	// Queries run in this order on one Searcher, so typed-out prefixes
+	// exercise the incremental narrowing and the rest exercise resets.
+	queries := []string{
+		"", "c", "ch", "cha", "char", "chari", "charz", "charizard", "charizardx",
+		"carizard", "charzard", "chrizard", "jiglypuf", "jigglypuf", "fanpee", "gengr",
+		"s", "su", "sur", "surf", "levitate", "mt silver", "tower", "lapras",
+		"25", "#025", "#25", "999", "mr. mime", "mr mime", "mrmime", "nidoran♀",
+		"farfetch'd", "リザードン", "りざーどん", "dracaufeu", "drâcaufeu", "charge",
+		".", "-", "type:fire", "type:fire c", "type:fire ch", "ability:levitate gas",
+		"atk>=80", "in:yellow learns:surf la", "misdreavus", "misdrevus", "gastli",
+	}
+	for _, input := range queries {
+		q, err := ParseQuery(input)
+		if err != nil {
+			t.Fatalf("%q: %v", input, err)
+		}
+		want := scanSearch(q, c)
+		if got := s.Search(q); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q:\n got %v\nwant %v", input, kinds(got), kinds(want))
+		}
+		if got := q.Search(c); !reflect.DeepEqual(got, want) {
+			t.Errorf("Query.Search(%q):\n got %v\nwant %v", input, kinds(got), kinds(want))
+		}
+	}
+}
+
+func TestSearcher_NarrowsWhileTyping(t *testing.T) {
+	useQueryTables(t)
+	s := NewSearcher(NewIndex(NewCorpus(indexFixture())))
+	var first, last int
+	for i, input := range []string{"g", "ga", "gas", "gast"} {
+		q, _ := ParseQuery(input)
+		s.Search(q)
+		if i > 0 && len(s.lastSubseq) > last {
+			t.Errorf("%q: %d subsequence candidates, up from %d", input, len(s.lastSubseq), last)
+		}
+		if i == 0 {
+			first = len(s.lastSubseq)
+		}
+		last = len(s.lastSubseq)
+	}
+	if last >= first {
+		t.Errorf("\"gast\" kept %d of %d candidates, want fewer", last, first)
+	}
+}
+
+// benchCorpus builds a corpus of n made-up names, many times the size of the
+// real dex, so the cost of scanning it shows.
+func benchCorpus(n int) *Corpus {
+	syllables := []string{"char", "bul", "ba", "saur", "pi", "ka", "chu", "mew", "two",
+		"gen", "gar", "dra", "go", "nite", "eev", "ee", "lap", "ras", "ona", "ix",
+		"zu", "bat", "mag", "ne", "mite", "tor", "chic", "sly", "ly", "don"}
+	seed := uint32(1)
+	next := func(k int) int {
+		seed = seed*1664525 + 1013904223
+		return int(seed>>16) % k
+	}
+	pokemon := make([]*data.Pokemon, n)
+	for i := range pokemon {
+		var b strings.Builder
+		for j := 2 + next(3); j > 0; j-- {
+			b.WriteString(syllables[next(len(syllables))])
+		}
+		pokemon[i] = &data.Pokemon{ID: uint16(i + 1), Name: b.String()}
+	}
+	c := &Corpus{Pokemon: pokemon}
+	for i := 0; i < n/4; i++ {
+		c.Moves = append(c.Moves, &data.Move{ID: data.MoveID(i + 1),
+			Name: fmt.Sprintf("%s-%s", syllables[next(len(syllables))], syllables[next(len(syllables))])})
+	}
+	sort.Slice(c.Moves, func(i, j int) bool { return c.Moves[i].Name < c.Moves[j].Name })
+	return c
+}
+
+var benchQueries = []string{"charizard", "pikachu", "dragonite", "xyzzy", "mag"}
+
+func parseBenchQueries(b *testing.B, inputs []string) []*Query {
+	b.Helper()
+	qs := make([]*Query, len(inputs))
+	for i, input := range inputs {
+		q, err := ParseQuery(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		qs[i] = q
+	}
+	return qs
+}
+
+func BenchmarkSearch_Scan(b *testing.B) {
+	c := benchCorpus(20000)
+	qs := parseBenchQueries(b, benchQueries)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scanSearch(qs[i%len(qs)], c)
+	}
+}
+
+func BenchmarkSearch_Index(b *testing.B) {
+	ix := NewIndex(benchCorpus(20000))
+	qs := parseBenchQueries(b, benchQueries)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewSearcher(ix).Search(qs[i%len(qs)])
+	}
+}
+
+// BenchmarkSearch_Typing types "dragonite" a letter at a time, as the search
+// screen sees it.
+func BenchmarkSearch_Typing(b *testing.B) {
+	c := benchCorpus(20000)
+	var inputs []string
+	for i := 1; i <= len("dragonite"); i++ {
+		inputs = append(inputs, "dragonite"[:i])
+	}
+	qs := parseBenchQueries(b, inputs)
+
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, q := range qs {
+				scanSearch(q, c)
+			}
+		}
+	})
+	b.Run("index", func(b *testing.B) {
+		s := NewSearcher(NewIndex(c))
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for _, q := range qs {
+				s.Search(q)
+			}
+		}
+	})
+}
+
+func BenchmarkNewIndex(b *testing.B) {
+	c := benchCorpus(20000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewIndex(c)
+	}
+}
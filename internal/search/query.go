@@ -0,0 +1,229 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// Query is a parsed search box input: structured filters such as
+// "type:fire atk>=100" plus free text matched fuzzily against names.
+//
+// Supported filters:
+//
+//	type:<type>            has the type in the query's generation
+//	gen<op><n>             generation the species was introduced in
+//	hp atk def spa spd spe bst <op><n>   base stats; op is one of : = > >= < <=
+//	learns:<move>          learns the move in the in: version, or any version
+//	ability:<ability>      has the ability (Gen 3 data)
+//	in:<version>           sets the game for type and learnset checks and
+//	                       limits results to species obtainable in it: caught
+//	                       wild or evolved from one that is
+type Query struct {
+	Text    string
+	Version data.GameVersion // 0 when no in: filter was given
+	filters []filterFunc
+}
+
+// filterFunc tests one species against one filter in generation gen.
+type filterFunc func(p *data.Pokemon, q *Query, gen data.Generation) bool
+
+// ParseError reports the offending token so the TUI can show it inline.
+type ParseError struct {
+	Token string
+	Msg   string
+}
+
+func (e *ParseError) Error() string { return fmt.Sprintf("%s: %s", e.Token, e.Msg) }
+
+var tokenRe = regexp.MustCompile(`^([a-z]+)(>=|<=|>|<|=|:)(.*)$`)
+
+// statKeys maps stat filter keys to their base stat.
+var statKeys = map[string]func(s data.BaseStats) int{
+	"hp":  func(s data.BaseStats) int { return int(s.HP) },
+	"atk": func(s data.BaseStats) int { return int(s.Attack) },
+	"def": func(s data.BaseStats) int { return int(s.Defense) },
+	"spa": func(s data.BaseStats) int { return int(s.SpecialAttack) },
+	"spd": func(s data.BaseStats) int { return int(s.SpecialDefense) },
+	"spe": func(s data.BaseStats) int { return int(s.Speed) },
+	"bst": func(s data.BaseStats) int {
+		return int(s.HP) + int(s.Attack) + int(s.Defense) + int(s.SpecialAttack) + int(s.SpecialDefense) + int(s.Speed)
+	},
+}
+
+// ParseQuery parses the search box input. Tokens that don't look like
+// key:value or key<op>value are free text.
+func ParseQuery(input string) (*Query, error) {
+	q := &Query{}
+	var text []string
+	for _, tok := range strings.Fields(input) {
+		m := tokenRe.FindStringSubmatch(strings.ToLower(tok))
+		if m == nil {
+			text = append(text, tok)
+			continue
+		}
+		key, op, val := m[1], m[2], m[3]
+		if val == "" {
+			continue // still being typed
+		}
+		f, err := q.parseFilter(key, op, val)
+		if err != nil {
+			return nil, &ParseError{tok, err.Error()}
+		}
+		if f != nil {
+			q.filters = append(q.filters, f)
+		}
+	}
+	q.Text = strings.Join(text, " ")
+	return q, nil
+}
+
+func (q *Query) parseFilter(key, op, val string) (filterFunc, error) {
+	if stat, ok := statKeys[key]; ok {
+		cmp, err := comparison(op, val)
+		if err != nil {
+			return nil, err
+		}
+		return func(p *data.Pokemon, _ *Query, _ data.Generation) bool { return cmp(stat(p.Stats)) }, nil
+	}
+
+	switch key {
+	case "gen":
+		cmp, err := comparison(op, val)
+		if err != nil {
+			return nil, err
+		}
+		return func(p *data.Pokemon, _ *Query, _ data.Generation) bool { return cmp(int(data.IntroducedIn(p.ID))) }, nil
+	}
+
+	if op != ":" && op != "=" {
+		return nil, fmt.Errorf("%s takes ':' not %q", key, op)
+	}
+	switch key {
+	case "type":
+		t, ok := data.ParsePokeType(val)
+		if !ok {
+			return nil, fmt.Errorf("unknown type")
+		}
+		return func(p *data.Pokemon, _ *Query, gen data.Generation) bool {
+			types := p.TypesForGen(gen)
+			return types[0] == t || types[1] == t
+		}, nil
+
+	case "in":
+		v, ok := data.ParseGameVersion(val)
+		if !ok {
+			return nil, fmt.Errorf("unknown version")
+		}
+		q.Version = v
+		return func(p *data.Pokemon, _ *Query, _ data.Generation) bool { return p.ObtainableIn(v) }, nil
+
+	case "learns":
+		id, ok := lookupMove(val)
+		if !ok {
+			return nil, fmt.Errorf("unknown move")
+		}
+		return func(p *data.Pokemon, q *Query, _ data.Generation) bool { return learns(p, q.Version, id) }, nil
+
+	case "ability":
+		id, ok := lookupAbility(val)
+		if !ok {
+			return nil, fmt.Errorf("unknown ability")
+		}
+		return func(p *data.Pokemon, _ *Query, _ data.Generation) bool {
+			return p.Abilities[0] == id || p.Abilities[1] == id
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown filter %q", key)
+}
+
+// comparison returns a predicate comparing a value against val with op.
+func comparison(op, val string) (func(int) bool, error) {
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a number", val)
+	}
+	switch op {
+	case ">=":
+		return func(x int) bool { return x >= n }, nil
+	case "<=":
+		return func(x int) bool { return x <= n }, nil
+	case ">":
+		return func(x int) bool { return x > n }, nil
+	case "<":
+		return func(x int) bool { return x < n }, nil
+	}
+	return func(x int) bool { return x == n }, nil
+}
+
+func lookupMove(name string) (data.MoveID, bool) {
+	n := data.NormalizeName(name)
+	for _, m := range data.AllMoves {
+		if m != nil && data.NormalizeName(m.Name) == n {
+			return m.ID, true
+		}
+	}
+	return 0, false
+}
+
+func lookupAbility(name string) (data.AbilityID, bool) {
+	n := data.NormalizeName(name)
+	for _, a := range data.AllAbilities {
+		if a != nil && data.NormalizeName(a.Name) == n {
+			return a.ID, true
+		}
+	}
+	return 0, false
+}
+
+// learns reports whether p learns the move in version by any method, or in
+// any version when version is 0.
+func learns(p *data.Pokemon, version data.GameVersion, id data.MoveID) bool {
+	for _, vls := range p.Moves {
+		if version != 0 && vls.Version != version {
+			continue
+		}
+		for _, lm := range vls.Moves {
+			if lm.MoveID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Generation is the generation filters are evaluated in: the in: version's,
+// or Gen 3 when none was given.
+func (q *Query) Generation() data.Generation {
+	if q.Version == 0 {
+		return 3
+	}
+	return data.GenForVersion(q.Version)
+}
+
+// Match reports whether p passes every structured filter; free text is
+// handled by Filter's ranking.
+func (q *Query) Match(p *data.Pokemon) bool {
+	gen := q.Generation()
+	for _, f := range q.filters {
+		if !f(p, q, gen) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the species in c that pass the structured filters, ranked
+// by the free text through c's index.
+func (q *Query) Filter(c *Corpus) []*data.Pokemon {
+	var pokemon []*data.Pokemon
+	for _, r := range q.Search(c) {
+		if r.Kind == KindPokemon {
+			pokemon = append(pokemon, r.Pokemon)
+		}
+	}
+	return pokemon
+}
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// queryFixture carries the fields the structured filters look at.
+var queryFixture = []*data.Pokemon{
+	{ID: 6, Name: "charizard", Types: [2]data.PokeType{data.TypeFire, data.TypeFlying},
+		Stats: data.BaseStats{HP: 78, Attack: 84, Defense: 78, SpecialAttack: 109, SpecialDefense: 85, Speed: 100}},
+	{ID: 81, Name: "magnemite", Types: [2]data.PokeType{data.TypeElectric, data.TypeSteel},
+		Locations: []data.Location{{Game: data.GameRed}, {Game: data.GameGold}},
+		PastTypes: []data.PokemonTypePast{{UntilGen: 1, Types: [2]data.PokeType{data.TypeElectric, data.TypeNone}}}},
+	{ID: 92, Name: "gastly", Types: [2]data.PokeType{data.TypeGhost, data.TypePoison},
+		Abilities: [2]data.AbilityID{26, 0}, Locations: []data.Location{{Game: data.GameRed}}},
+	{ID: 131, Name: "lapras", Types: [2]data.PokeType{data.TypeWater, data.TypeIce},
+		Stats:     data.BaseStats{HP: 130, Attack: 85, Defense: 80, SpecialAttack: 85, SpecialDefense: 95, Speed: 60},
+		Moves:     []data.VersionedLearnset{{Version: data.GameYellow, Moves: []data.LearnedMove{{MoveID: 57}}}},
+		Locations: []data.Location{{Game: data.GameYellow}, {Game: data.GameGold}}},
+	{ID: 157, Name: "typhlosion", Types: [2]data.PokeType{data.TypeFire, data.TypeNone},
+		Stats: data.BaseStats{HP: 78, Attack: 84, Defense: 78, SpecialAttack: 109, SpecialDefense: 85, Speed: 100},
+		Moves: []data.VersionedLearnset{{Version: data.GameGold, Moves: []data.LearnedMove{{MoveID: 57}}}}},
+	{ID: 255, Name: "torchic", Types: [2]data.PokeType{data.TypeFire, data.TypeNone},
+		Stats: data.BaseStats{HP: 45, Attack: 60, Defense: 40, SpecialAttack: 70, SpecialDefense: 50, Speed: 45}},
+}
+
+func useQueryTables(t *testing.T) {
+	t.Helper()
+	moves, abilities := data.AllMoves, data.AllAbilities
+	t.Cleanup(func() { data.AllMoves, data.AllAbilities = moves, abilities })
+	data.AllMoves = make([]*data.Move, 60)
+	data.AllMoves[57] = &data.Move{ID: 57, Name: "Surf"}
+	data.AllAbilities = make([]*data.Ability, 30)
+	data.AllAbilities[26] = &data.Ability{ID: 26, Name: "levitate"}
+}
+
+func queryNames(t *testing.T, input string) string {
+	t.Helper()
+	q, err := ParseQuery(input)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q): %v", input, err)
+	}
+	return strings.Join(names(q.Filter(&Corpus{Pokemon: queryFixture})), ",")
+}
+
+func names(ps []*data.Pokemon) []string {
+	out := make([]string, len(ps))
+	for i, p := range ps {
+		out[i] = p.Name
+	}
+	return out
+}
+
+func TestQuery_Filters(t *testing.T) {
+	useQueryTables(t)
+	tests := []struct {
+		input, want string
+	}{
+		{"type:fire", "charizard,typhlosion,torchic"},
+		{"type:fire gen:1", "charizard"},
+		{"type:fire gen>=2", "typhlosion,torchic"},
+		{"atk>=80 bst>500", "charizard,lapras,typhlosion"},
+		{"spa=109 spe<101", "charizard,typhlosion"},
+		{"learns:surf", "lapras,typhlosion"},
+		{"learns:surf in:yellow", "lapras"},
+		{"in:gold", "magnemite,lapras"},
+		{"in:red type:ghost", "gastly"},
+		{"ability:levitate", "gastly"},
+		{"type:fire char", "charizard"},
+		{"TYPE:Fire torch", "torchic"},
+	}
+	for _, tt := range tests {
+		if got := queryNames(t, tt.input); got != tt.want {
+			t.Errorf("%q = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestQuery_TypesFollowGeneration(t *testing.T) {
+	useQueryTables(t)
+	if got := queryNames(t, "type:steel"); got != "magnemite" {
+		t.Errorf("type:steel = %q, want magnemite", got)
+	}
+	if got := queryNames(t, "type:steel in:red"); got != "" {
+		t.Errorf("type:steel in:red = %q, want none (pure Electric in Gen 1)", got)
+	}
+}
+
+func TestQuery_ParseErrors(t *testing.T) {
+	useQueryTables(t)
+	for _, input := range []string{"type:plasma", "atk>=lots", "learns:hyperbeam", "foo:bar", "in:diamond", "type>fire"} {
+		_, err := ParseQuery(input)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseQuery(%q) err = %v, want *ParseError", input, err)
+		}
+	}
+}
+
+func TestQuery_EmptyValueIsStillBeingTyped(t *testing.T) {
+	useQueryTables(t)
+	for _, input := range []string{"type:", "char type:", "atk>="} {
+		if _, err := ParseQuery(input); err != nil {
+			t.Errorf("ParseQuery(%q) = %v, want no error", input, err)
+		}
+	}
+	if got := queryNames(t, "char type:"); got != "charizard" {
+		t.Errorf("char type: = %q, want charizard", got)
+	}
+}
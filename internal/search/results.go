@@ -0,0 +1,148 @@
+package search
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// Kind is what a search result refers to.
+type Kind byte
+
+const (
+	KindPokemon  Kind = 0
+	KindMove     Kind = 1
+	KindAbility  Kind = 2
+	KindLocation Kind = 3
+)
+
+var kindNames = [4]string{"Pokémon", "Move", "Ability", "Location"}
+
+func (k Kind) String() string { return kindNames[k] }
+
+// kindBonus orders kinds on equal match quality: Pokémon, then moves,
+// abilities and locations.
+var kindBonus = [4]int{3, 2, 1, 0}
+
+// minOtherKindQuery is the shortest free text that also searches moves,
+// abilities and locations; a single letter would list most of them.
+const minOtherKindQuery = 2
+
+// Result is one search hit. Exactly one of Pokemon, Move, Ability or Area is set,
+// matching Kind. Tier, Positions and Lang say how the query text matched; they
+// are zero when there was no text to match.
+type Result struct {
+	Kind    Kind
+	Pokemon *data.Pokemon
+	Move    *data.Move
+	Ability *data.Ability
+	Area    *Area
+	Tier    Tier
+	// Positions are rune indices into Name when Lang is English, and into
+	// Names()[Lang] otherwise.
+	Positions []int
+	Lang      data.Language
+	score     int
+	dist      int
+}
+
+// Name returns the result's display name.
+func (r Result) Name() string {
+	switch r.Kind {
+	case KindMove:
+		return data.DisplayName(r.Move.Name)
+	case KindAbility:
+		return data.DisplayName(r.Ability.Name)
+	case KindLocation:
+		return data.DisplayName(r.Area.Name)
+	}
+	return data.DisplayName(r.Pokemon.Name)
+}
+
+// Names returns the result's localized names; locations have none.
+func (r Result) Names() data.LocalNames {
+	switch r.Kind {
+	case KindMove:
+		return r.Move.Names
+	case KindAbility:
+		return r.Ability.Names
+	case KindLocation:
+		return data.LocalNames{}
+	}
+	return r.Pokemon.Names
+}
+
+// Area is a location area and the species encountered there in any version.
+type Area struct {
+	Name    string
+	Pokemon []*data.Pokemon
+}
+
+// Corpus is everything the search box can find. It is indexed the first
+// time it's searched, so it mustn't change after that.
+type Corpus struct {
+	Pokemon   []*data.Pokemon
+	Moves     []*data.Move
+	Abilities []*data.Ability
+	Areas     []*Area
+
+	indexOnce sync.Once
+	index     *Index
+}
+
+// Index returns the corpus's index, building it on first use.
+func (c *Corpus) Index() *Index {
+	c.indexOnce.Do(func() { c.index = NewIndex(c) })
+	return c.index
+}
+
+// NewCorpus collects the areas from the pokemon's encounter data and takes
+// the moves and abilities from the loaded tables.
+func NewCorpus(pokemon []*data.Pokemon) *Corpus {
+	c := &Corpus{Pokemon: pokemon}
+	for _, m := range data.AllMoves {
+		if m != nil {
+			c.Moves = append(c.Moves, m)
+		}
+	}
+	for _, a := range data.AllAbilities {
+		if a != nil {
+			c.Abilities = append(c.Abilities, a)
+		}
+	}
+	byName := make(map[string]*Area)
+	for _, p := range pokemon {
+		for _, loc := range p.Locations {
+			a, ok := byName[loc.AreaName]
+			if !ok {
+				a = &Area{Name: loc.AreaName}
+				byName[loc.AreaName] = a
+				c.Areas = append(c.Areas, a)
+			}
+			if n := len(a.Pokemon); n == 0 || a.Pokemon[n-1] != p {
+				a.Pokemon = append(a.Pokemon, p)
+			}
+		}
+	}
+	sort.Slice(c.Areas, func(i, j int) bool { return c.Areas[i].Name < c.Areas[j].Name })
+	return c
+}
+
+// Search returns typed results ranked by score. Structured filters only
+// apply to Pokémon, so a query with filters returns Pokémon only; an empty
+// query returns every Pokémon in dex order. It uses c's index; keep a
+// Searcher to narrow results as a query is typed.
+func (q *Query) Search(c *Corpus) []Result {
+	return NewSearcher(c.Index()).Search(q)
+}
+
+// setMatch records how r matched the query text, reporting whether it
+// matched at all.
+func (r *Result) setMatch(m nameMatch) bool {
+	r.score, r.dist = m.score+kindBonus[r.Kind], m.dist
+	r.Tier = scoreTiers[m.score]
+	r.Positions = m.positions
+	r.Lang = m.lang
+	return m.score > scoreNoMatch
+}
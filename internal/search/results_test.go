@@ -0,0 +1,220 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var corpusFixture = []*data.Pokemon{
+	{ID: 92, Name: "gastly", Abilities: [2]data.AbilityID{26, 0},
+		Locations: []data.Location{{Game: data.GameRed, AreaName: "pokemon-tower-3f"}}},
+	{ID: 131, Name: "lapras"},
+	{ID: 138, Name: "omanyte"},
+	{ID: 200, Name: "misdreavus", Abilities: [2]data.AbilityID{26, 0},
+		Locations: []data.Location{{Game: data.GameGold, AreaName: "mt-silver"}, {Game: data.GameSilver, AreaName: "mt-silver"}}},
+}
+
+func kinds(rs []Result) []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = r.Kind.String() + ":" + r.Name()
+	}
+	return out
+}
+
+func searchNames(t *testing.T, input string) []string {
+	t.Helper()
+	q, err := ParseQuery(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kinds(q.Search(NewCorpus(corpusFixture)))
+}
+
+func TestSearch_FindsEveryKind(t *testing.T) {
+	useQueryTables(t)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"surf", "Move:Surf"},
+		{"levitate", "Ability:Levitate"},
+		{"mt silver", "Location:Mt Silver"},
+		{"tower", "Location:Pokemon Tower 3f"},
+		{"lapras", "Pokémon:Lapras"},
+	}
+	for _, tt := range tests {
+		got := searchNames(t, tt.input)
+		if len(got) == 0 || got[0] != tt.want {
+			t.Errorf("%q = %v, want %s first", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSearch_PokemonRankAheadOnEqualScore(t *testing.T) {
+	useQueryTables(t)
+	data.AllMoves[58] = &data.Move{ID: 58, Name: "Omanyte"} // a made-up clash
+	got := searchNames(t, "omanyte")
+	if len(got) != 2 || got[0] != "Pokémon:Omanyte" || got[1] != "Move:Omanyte" {
+		t.Errorf("got %v, want the Pokémon before the move", got)
+	}
+}
+
+func TestSearch_OtherKindsNeedTextWithoutFilters(t *testing.T) {
+	useQueryTables(t)
+	if got := searchNames(t, "s"); len(got) != 3 {
+		// gastly, lapras, misdreavus: a single letter only searches Pokémon.
+		t.Errorf("\"s\" = %v, want 3 Pokémon", got)
+	}
+	if got := searchNames(t, "surf ability:levitate"); len(got) != 0 {
+		t.Errorf("filtered query = %v, want no moves mixed in", got)
+	}
+	if got := searchNames(t, ""); len(got) != len(corpusFixture) {
+		t.Errorf("empty query = %v, want every Pokémon", got)
+	}
+}
+
+func TestNewCorpus_Areas(t *testing.T) {
+	c := NewCorpus(corpusFixture)
+	if len(c.Areas) != 2 || c.Areas[0].Name != "mt-silver" || len(c.Areas[0].Pokemon) != 1 {
+		t.Errorf("Areas = %+v, want mt-silver (misdreavus once) and pokemon-tower-3f", c.Areas)
+	}
+}
+
+func TestSearch_TierAndPositions(t *testing.T) {
+	useQueryTables(t)
+	tests := []struct {
+		input string
+		tier  Tier
+		want  []int
+	}{
+		{"lapras", TierExact, []int{0, 1, 2, 3, 4, 5}},
+		{"ras", TierContains, []int{3, 4, 5}},
+		{"lprs", TierSubsequence, []int{0, 2, 3, 5}},
+		{"lapraz", TierTypo, []int{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs := q.Search(NewCorpus(corpusFixture))
+		if len(rs) == 0 || rs[0].Name() != "Lapras" {
+			t.Fatalf("%q = %v, want Lapras first", tt.input, kinds(rs))
+		}
+		if rs[0].Tier != tt.tier || !slices.Equal(rs[0].Positions, tt.want) {
+			t.Errorf("%q: tier %v positions %v, want %v %v", tt.input, rs[0].Tier, rs[0].Positions, tt.tier, tt.want)
+		}
+	}
+}
+
+func TestSearch_PositionsIndexDisplayName(t *testing.T) {
+	useQueryTables(t)
+	q, _ := ParseQuery("silver")
+	rs := q.Search(NewCorpus(corpusFixture))
+	if len(rs) == 0 || rs[0].Name() != "Mt Silver" {
+		t.Fatalf("got %v, want Mt Silver first", kinds(rs))
+	}
+	if want := []int{3, 4, 5, 6, 7, 8}; !slices.Equal(rs[0].Positions, want) {
+		t.Errorf("positions = %v, want %v", rs[0].Positions, want)
+	}
+}
+
+func TestSearch_LocalizedNames(t *testing.T) {
+	useQueryTables(t)
+	data.AllMoves[57].Names = data.LocalNames{"Surf", "なみのり", "Naminori", "Surf", "Surfer", "Surf", "Surf", "파도타기"}
+	gastly := *corpusFixture[0]
+	gastly.Names = data.LocalNames{"Gastly", "ゴース", "Ghos", "Fantominus", "Nebulak", "Gastly", "Gastly", "고오스"}
+	corpus := NewCorpus([]*data.Pokemon{&gastly, corpusFixture[1]})
+
+	tests := []struct {
+		input     string
+		want      string
+		lang      data.Language
+		positions []int
+	}{
+		{"ゴース", "Pokémon:Gastly", data.LangJapanese, []int{0, 1, 2}},
+		{"ごーす", "Pokémon:Gastly", data.LangJapanese, []int{0, 1, 2}},
+		{"ghos", "Pokémon:Gastly", data.LangRomaji, []int{0, 1, 2, 3}},
+		{"fantom", "Pokémon:Gastly", data.LangFrench, []int{0, 1, 2, 3, 4, 5}},
+		{"なみのり", "Move:Surf", data.LangJapanese, []int{0, 1, 2, 3}},
+		{"naminori", "Move:Surf", data.LangRomaji, []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{"파도", "Move:Surf", data.LangKorean, []int{0, 1}},
+		{"gastly", "Pokémon:Gastly", data.LangEnglish, []int{0, 1, 2, 3, 4, 5}},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs := q.Search(corpus)
+		if len(rs) == 0 || kinds(rs[:1])[0] != tt.want {
+			t.Errorf("%q = %v, want %s first", tt.input, kinds(rs), tt.want)
+			continue
+		}
+		if rs[0].Lang != tt.lang || !slices.Equal(rs[0].Positions, tt.positions) {
+			t.Errorf("%q: lang %v positions %v, want %v %v", tt.input, rs[0].Lang, rs[0].Positions, tt.lang, tt.positions)
+		}
+	}
+}
+
+func TestSearch_AccentsFoldOntoLocalizedName(t *testing.T) {
+	p := &data.Pokemon{ID: 133, Name: "eevee", Names: data.LocalNames{3: "Évoli"}}
+	m := scorePokemon(p, "evoli")
+	if m.score != scoreExact || m.lang != data.LangFrench {
+		t.Errorf("evoli: score %d lang %v, want exact French", m.score, m.lang)
+	}
+	// Positions skip the apostrophe the query leaves out.
+	if m := matchNames("Farfetch'd", data.LocalNames{}, "farfetchd"); !slices.Equal(m.positions, []int{0, 1, 2, 3, 4, 5, 6, 7, 9}) {
+		t.Errorf("positions = %v, want the apostrophe skipped", m.positions)
+	}
+}
+
+func TestSearcher_FilterMoves(t *testing.T) {
+	moves := []*data.Move{
+		{ID: 57, Name: "surf"},
+		{ID: 53, Name: "flamethrower", Names: data.LocalNames{data.LangFrench: "Lance-Flammes"}},
+		{ID: 52, Name: "ember"},
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"Move:Surf", "Move:Flamethrower", "Move:Ember"}},
+		{"em", []string{"Move:Ember", "Move:Flamethrower"}},
+		{"lance", []string{"Move:Flamethrower"}},
+		{"srf", []string{"Move:Surf"}},
+		{"xyz", nil},
+	}
+	c := &Corpus{Pokemon: corpusFixture, Moves: moves}
+	s := NewSearcher(c.Index())
+	for _, tt := range tests {
+		if got := kinds(s.Filter(KindMove, tt.query)); !slices.Equal(got, tt.want) {
+			t.Errorf("Filter(KindMove, %q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearcher_FilterAbilities(t *testing.T) {
+	abilities := []*data.Ability{
+		{ID: 26, Name: "levitate"},
+		{ID: 65, Name: "overgrow", Names: data.LocalNames{data.LangFrench: "Engrais"}},
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"Ability:Levitate", "Ability:Overgrow"}},
+		{"engrais", []string{"Ability:Overgrow"}},
+		{"lev", []string{"Ability:Levitate"}},
+	}
+	c := &Corpus{Pokemon: corpusFixture, Abilities: abilities}
+	s := NewSearcher(c.Index())
+	for _, tt := range tests {
+		if got := kinds(s.Filter(KindAbility, tt.query)); !slices.Equal(got, tt.want) {
+			t.Errorf("Filter(KindAbility, %q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// SortMode orders search results. SortRelevance keeps the ranked order;
+// the others order Pokémon by a field, with moves, abilities and locations
+// after them in ranked order.
+type SortMode byte
+
+const (
+	SortRelevance   SortMode = 0
+	SortDex         SortMode = 1
+	SortName        SortMode = 2
+	SortTotal       SortMode = 3
+	SortHP          SortMode = 4
+	SortAttack      SortMode = 5
+	SortDefense     SortMode = 6
+	SortSpAttack    SortMode = 7
+	SortSpDefense   SortMode = 8
+	SortSpeed       SortMode = 9
+	SortHeight      SortMode = 10
+	SortWeight      SortMode = 11
+	SortCaptureRate SortMode = 12
+)
+
+// NumSortModes is the number of SortMode values, for cycling through them.
+const NumSortModes = 13
+
+var sortModeNames = [NumSortModes]string{
+	"Relevance", "Dex no.", "Name", "Base stat total", "HP", "Attack", "Defense",
+	"Sp. Atk", "Sp. Def", "Speed", "Height", "Weight", "Capture rate",
+}
+
+// sortModeCodes are the short codes accepted by ParseSortMode; the stats
+// reuse the query's stat keys.
+var sortModeCodes = [NumSortModes]string{
+	"relevance", "dex", "name", "bst", "hp", "atk", "def", "spa", "spd", "spe", "height", "weight", "capture",
+}
+
+func (s SortMode) String() string { return sortModeNames[s] }
+
+// Code returns the mode's short code, as saved in the settings.
+func (s SortMode) Code() string { return sortModeCodes[s] }
+
+// ParseSortMode looks up a sort mode by its code, case-insensitively.
+func ParseSortMode(code string) (SortMode, bool) {
+	for i := range SortMode(NumSortModes) {
+		if strings.EqualFold(sortModeCodes[i], code) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// sortKeys gives the numeric key of each field mode. Larger values sort
+// first: the strongest, biggest and easiest to catch Pokémon lead.
+var sortKeys = map[SortMode]func(p *data.Pokemon) int{
+	SortTotal:       func(p *data.Pokemon) int { return statKeys["bst"](p.Stats) },
+	SortHP:          func(p *data.Pokemon) int { return statKeys["hp"](p.Stats) },
+	SortAttack:      func(p *data.Pokemon) int { return statKeys["atk"](p.Stats) },
+	SortDefense:     func(p *data.Pokemon) int { return statKeys["def"](p.Stats) },
+	SortSpAttack:    func(p *data.Pokemon) int { return statKeys["spa"](p.Stats) },
+	SortSpDefense:   func(p *data.Pokemon) int { return statKeys["spd"](p.Stats) },
+	SortSpeed:       func(p *data.Pokemon) int { return statKeys["spe"](p.Stats) },
+	SortHeight:      func(p *data.Pokemon) int { return int(p.Height) },
+	SortWeight:      func(p *data.Pokemon) int { return int(p.Weight) },
+	SortCaptureRate: func(p *data.Pokemon) int { return int(p.CaptureRate) },
+}
+
+// SortResults reorders results in place by mode. Names sort as shown in
+// lang, falling back to the dex name. Ties go to the lower dex number.
+func SortResults(results []Result, mode SortMode, lang data.Language) {
+	if mode == SortRelevance {
+		return
+	}
+	name := func(p *data.Pokemon) string {
+		if n := p.Names[lang]; n != "" && lang != data.LangEnglish {
+			return strings.ToLower(n)
+		}
+		return p.Name
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].Pokemon, results[j].Pokemon
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		switch mode {
+		case SortName:
+			if na, nb := name(a), name(b); na != nb {
+				return na < nb
+			}
+		case SortDex:
+		default:
+			if ka, kb := sortKeys[mode](a), sortKeys[mode](b); ka != kb {
+				return ka > kb
+			}
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,65 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var sortFixture = []*data.Pokemon{
+	{ID: 25, Name: "pikachu", Height: 4, Weight: 60, CaptureRate: 190,
+		Stats: data.BaseStats{HP: 35, Attack: 55, Defense: 40, SpecialAttack: 50, SpecialDefense: 50, Speed: 90},
+		Names: data.LocalNames{data.LangJapanese: "ピカチュウ"}},
+	{ID: 6, Name: "charizard", Height: 17, Weight: 905, CaptureRate: 45,
+		Stats: data.BaseStats{HP: 78, Attack: 84, Defense: 78, SpecialAttack: 109, SpecialDefense: 85, Speed: 100},
+		Names: data.LocalNames{data.LangJapanese: "リザードン"}},
+	{ID: 1, Name: "bulbasaur", Height: 7, Weight: 69, CaptureRate: 45,
+		Stats: data.BaseStats{HP: 45, Attack: 49, Defense: 49, SpecialAttack: 65, SpecialDefense: 65, Speed: 45},
+		Names: data.LocalNames{data.LangJapanese: "フシギダネ"}},
+}
+
+func TestSortResults(t *testing.T) {
+	surf := Result{Kind: KindMove, Move: &data.Move{Name: "surf"}}
+	tests := []struct {
+		mode SortMode
+		lang data.Language
+		want []string
+	}{
+		{SortRelevance, data.LangEnglish, []string{"Pikachu", "Surf", "Charizard", "Bulbasaur"}},
+		{SortDex, data.LangEnglish, []string{"Bulbasaur", "Charizard", "Pikachu", "Surf"}},
+		{SortName, data.LangEnglish, []string{"Bulbasaur", "Charizard", "Pikachu", "Surf"}},
+		{SortName, data.LangJapanese, []string{"Pikachu", "Bulbasaur", "Charizard", "Surf"}},
+		{SortTotal, data.LangEnglish, []string{"Charizard", "Pikachu", "Bulbasaur", "Surf"}},
+		{SortSpeed, data.LangEnglish, []string{"Charizard", "Pikachu", "Bulbasaur", "Surf"}},
+		{SortSpDefense, data.LangEnglish, []string{"Charizard", "Bulbasaur", "Pikachu", "Surf"}},
+		{SortWeight, data.LangEnglish, []string{"Charizard", "Bulbasaur", "Pikachu", "Surf"}},
+		// Charizard and Bulbasaur tie on 45 and fall back to dex order.
+		{SortCaptureRate, data.LangEnglish, []string{"Pikachu", "Bulbasaur", "Charizard", "Surf"}},
+	}
+	for _, tt := range tests {
+		results := []Result{
+			{Kind: KindPokemon, Pokemon: sortFixture[0]}, surf,
+			{Kind: KindPokemon, Pokemon: sortFixture[1]}, {Kind: KindPokemon, Pokemon: sortFixture[2]},
+		}
+		SortResults(results, tt.mode, tt.lang)
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Name())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%v in %v = %v, want %v", tt.mode, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestParseSortMode(t *testing.T) {
+	for mode := range SortMode(NumSortModes) {
+		if got, ok := ParseSortMode(mode.Code()); !ok || got != mode {
+			t.Errorf("ParseSortMode(%q) = %v, %v; want %v", mode.Code(), got, ok, mode)
+		}
+	}
+	if _, ok := ParseSortMode("speed"); ok {
+		t.Error("ParseSortMode(\"speed\") succeeded, want only codes")
+	}
+}
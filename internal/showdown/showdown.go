@@ -0,0 +1,344 @@
+// Package showdown reads and writes Pokémon Showdown's team paste format.
+package showdown
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+)
+
+// Set is one Pokémon of a team. Zero values mean "not given": Showdown then
+// assumes level 100, 0 EVs and 31 IVs.
+type Set struct {
+	Line     int // line number of the species line, for reporting
+	Nickname string
+	Pokemon  *data.Pokemon // nil if the species wasn't recognised
+	Species  string        // as written, kept when Pokemon is nil
+	Gender   string        // "M", "F" or ""
+	Item     string        // items aren't in the dex, so kept as written
+	Ability  data.AbilityID
+	Level    uint8
+	Shiny    bool
+	Nature   string // canonical nature name, or ""
+	EVs      save.Stats
+	IVs      save.Stats
+	HasIVs   bool
+	Moves    []data.MoveID
+}
+
+// Problem is a line that couldn't be resolved or a move the Pokémon can't learn.
+type Problem struct {
+	Line int
+	Msg  string
+}
+
+func (p Problem) String() string { return fmt.Sprintf("line %d: %s", p.Line, p.Msg) }
+
+// index maps normalised names to dex entries; built per Parse so it sees the
+// tables as they are (tests swap them out).
+type index struct {
+	pokemon   map[string]*data.Pokemon
+	moves     map[string]data.MoveID
+	abilities map[string]data.AbilityID
+}
+
+func newIndex() index {
+	ix := index{
+		pokemon:   make(map[string]*data.Pokemon, len(data.ByName)),
+		moves:     make(map[string]data.MoveID, len(data.AllMoves)),
+		abilities: make(map[string]data.AbilityID, len(data.AllAbilities)),
+	}
+	for name, p := range data.ByName {
+		ix.pokemon[data.NormalizeName(name)] = p
+	}
+	for _, m := range data.AllMoves {
+		if m != nil {
+			ix.moves[data.NormalizeName(m.Name)] = m.ID
+		}
+	}
+	for _, a := range data.AllAbilities {
+		if a != nil {
+			ix.abilities[data.NormalizeName(a.Name)] = a.ID
+		}
+	}
+	return ix
+}
+
+// statNames are Showdown's stat abbreviations in EV/IV lines.
+var statNames = [6]string{"HP", "Atk", "Def", "SpA", "SpD", "Spe"}
+
+func statField(s *save.Stats, i int) *uint16 {
+	return [6]*uint16{&s.HP, &s.Attack, &s.Defense, &s.SpecialAttack, &s.SpecialDefense, &s.Speed}[i]
+}
+
+// Parse reads a team paste. Moves are checked against the learnset for
+// version; unknown names and unlearnable moves are returned as problems, and
+// only read errors are returned as an error.
+func Parse(r io.Reader, version data.GameVersion) ([]Set, []Problem, error) {
+	ix := newIndex()
+	var (
+		sets     []Set
+		problems []Problem
+		cur      *Set
+	)
+	report := func(line int, format string, args ...any) {
+		problems = append(problems, Problem{Line: line, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "==="):
+			cur = nil
+
+		case cur == nil:
+			sets = append(sets, parseHeader(line, n))
+			cur = &sets[len(sets)-1]
+			if p, ok := ix.pokemon[data.NormalizeName(cur.Species)]; ok {
+				cur.Pokemon = p
+			} else {
+				report(n, "unknown Pokémon %q", cur.Species)
+			}
+
+		case strings.HasPrefix(line, "- "):
+			name := strings.TrimSpace(line[2:])
+			if i := strings.Index(name, "["); i >= 0 { // Hidden Power [Fire]
+				name = name[:i]
+			}
+			id, ok := ix.moves[data.NormalizeName(name)]
+			if !ok {
+				report(n, "unknown move %q", name)
+				continue
+			}
+			cur.Moves = append(cur.Moves, id)
+			if cur.Pokemon != nil && !canLearn(cur.Pokemon, version, id) {
+				report(n, "%s can't learn %s in %s", pokemonName(cur.Pokemon), moveName(id), version)
+			}
+
+		case strings.HasPrefix(line, "Ability:"):
+			name := strings.TrimSpace(strings.TrimPrefix(line, "Ability:"))
+			if id, ok := ix.abilities[data.NormalizeName(name)]; ok {
+				cur.Ability = id
+			} else {
+				report(n, "unknown ability %q", name)
+			}
+
+		case strings.HasPrefix(line, "Level:"):
+			lv, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Level:")))
+			if err != nil || lv < 1 || lv > 100 {
+				report(n, "invalid level")
+				continue
+			}
+			cur.Level = uint8(lv)
+
+		case strings.HasPrefix(line, "Shiny:"):
+			cur.Shiny = strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(line, "Shiny:")), "yes")
+
+		case strings.HasPrefix(line, "EVs:"):
+			if err := parseSpread(&cur.EVs, strings.TrimPrefix(line, "EVs:")); err != nil {
+				report(n, "EVs: %v", err)
+			}
+
+		case strings.HasPrefix(line, "IVs:"):
+			cur.IVs = save.Stats{HP: 31, Attack: 31, Defense: 31, SpecialAttack: 31, SpecialDefense: 31, Speed: 31}
+			cur.HasIVs = true
+			if err := parseSpread(&cur.IVs, strings.TrimPrefix(line, "IVs:")); err != nil {
+				report(n, "IVs: %v", err)
+			}
+
+		case strings.HasSuffix(line, " Nature"):
+			name := strings.TrimSuffix(line, " Nature")
+			if nat, ok := save.ParseNature(name); ok {
+				cur.Nature = nat.String()
+			} else {
+				report(n, "unknown nature %q", name)
+			}
+
+		default:
+			// Tera types, happiness, etc. from later generations are ignored.
+		}
+	}
+	return sets, problems, sc.Err()
+}
+
+// parseHeader splits "Nickname (Species) (F) @ Item".
+func parseHeader(line string, n int) Set {
+	s := Set{Line: n}
+	if i := strings.LastIndex(line, " @ "); i >= 0 {
+		s.Item = strings.TrimSpace(line[i+3:])
+		line = strings.TrimSpace(line[:i])
+	}
+	for _, g := range []string{"M", "F"} {
+		if strings.HasSuffix(line, " ("+g+")") {
+			s.Gender = g
+			line = strings.TrimSuffix(line, " ("+g+")")
+		}
+	}
+	if i := strings.LastIndex(line, " ("); i >= 0 && strings.HasSuffix(line, ")") {
+		s.Nickname = line[:i]
+		line = line[i+2 : len(line)-1]
+	}
+	s.Species = line
+	return s
+}
+
+// parseSpread reads "252 SpA / 4 SpD / 252 Spe" into s.
+func parseSpread(s *save.Stats, text string) error {
+	for _, part := range strings.Split(text, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return fmt.Errorf("malformed %q", strings.TrimSpace(part))
+		}
+		v, err := strconv.Atoi(fields[0])
+		if err != nil || v < 0 || v > 255 {
+			return fmt.Errorf("invalid value %q", fields[0])
+		}
+		found := false
+		for i, name := range statNames {
+			if strings.EqualFold(fields[1], name) {
+				*statField(s, i) = uint16(v)
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown stat %q", fields[1])
+		}
+	}
+	return nil
+}
+
+// canLearn reports whether the move is in the Pokémon's learnset for version
+// by any method. Versions without learnset data accept every move.
+func canLearn(p *data.Pokemon, version data.GameVersion, id data.MoveID) bool {
+	for _, vls := range p.Moves {
+		if vls.Version != version {
+			continue
+		}
+		for _, lm := range vls.Moves {
+			if lm.MoveID == id {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Write serialises sets as a Showdown paste, one blank line between sets.
+func Write(w io.Writer, sets []Set) error {
+	bw := bufio.NewWriter(w)
+	for i, s := range sets {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		species := s.Species
+		if s.Pokemon != nil {
+			species = pokemonName(s.Pokemon)
+		}
+		if s.Nickname != "" {
+			fmt.Fprintf(bw, "%s (%s)", s.Nickname, species)
+		} else {
+			bw.WriteString(species)
+		}
+		if s.Gender != "" {
+			fmt.Fprintf(bw, " (%s)", s.Gender)
+		}
+		if s.Item != "" {
+			fmt.Fprintf(bw, " @ %s", s.Item)
+		}
+		bw.WriteString("\n")
+		if a := abilityName(s.Ability); a != "" {
+			fmt.Fprintf(bw, "Ability: %s\n", a)
+		}
+		if s.Level != 0 && s.Level != 100 {
+			fmt.Fprintf(bw, "Level: %d\n", s.Level)
+		}
+		if s.Shiny {
+			bw.WriteString("Shiny: Yes\n")
+		}
+		if ev := spread(s.EVs, 0); ev != "" {
+			fmt.Fprintf(bw, "EVs: %s\n", ev)
+		}
+		if s.Nature != "" {
+			fmt.Fprintf(bw, "%s Nature\n", s.Nature)
+		}
+		if s.HasIVs {
+			if iv := spread(s.IVs, 31); iv != "" {
+				fmt.Fprintf(bw, "IVs: %s\n", iv)
+			}
+		}
+		for _, id := range s.Moves {
+			if name := moveName(id); name != "" {
+				fmt.Fprintf(bw, "- %s\n", name)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// spread formats the stats that differ from def, e.g. "252 Atk / 4 Def".
+func spread(s save.Stats, def uint16) string {
+	var parts []string
+	for i, name := range statNames {
+		if v := *statField(&s, i); v != def {
+			parts = append(parts, fmt.Sprintf("%d %s", v, name))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// pokemonName, moveName and abilityName are the English names Showdown
+// writes, such as "Mr. Mime" and "Thunder Punch".
+func pokemonName(p *data.Pokemon) string { return data.EnglishName(p.Name, p.Names) }
+
+func moveName(id data.MoveID) string {
+	m := data.MoveByID(id)
+	if m == nil {
+		return ""
+	}
+	return data.EnglishName(m.Name, m.Names)
+}
+
+func abilityName(id data.AbilityID) string {
+	if id == 0 || int(id) >= len(data.AllAbilities) || data.AllAbilities[id] == nil {
+		return ""
+	}
+	a := data.AllAbilities[id]
+	return data.EnglishName(a.Name, a.Names)
+}
+
+// FromSave converts a Pokémon read from a save or .pk file into a set. Gen 1-2
+// DVs and stat experience have no Showdown equivalent and are left out.
+func FromSave(p *save.Pokemon) Set {
+	s := Set{
+		Nickname: p.Nickname,
+		Pokemon:  p.Data(),
+		Species:  fmt.Sprintf("#%d", p.Species),
+		Level:    p.Level,
+	}
+	if s.Pokemon != nil && strings.EqualFold(s.Nickname, s.Pokemon.Name) {
+		s.Nickname = ""
+	}
+	for _, id := range p.Moves {
+		if id != 0 {
+			s.Moves = append(s.Moves, id)
+		}
+	}
+	if p.Gen >= 2 {
+		s.Shiny = p.Shiny()
+	}
+	if p.Gen >= 3 {
+		s.EVs = p.EVs
+		s.IVs = p.IVs
+		s.HasIVs = true
+		s.Nature = p.Nature().String()
+		s.Ability = p.Ability()
+	}
+	return s
+}
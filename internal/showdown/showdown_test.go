@@ -0,0 +1,163 @@
+package showdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+)
+
+// useFixture swaps in a tiny dex for the duration of a test.
+func useFixture(t *testing.T) {
+	t.Helper()
+	byID, byName, moves, abilities := data.ByID, data.ByName, data.AllMoves, data.AllAbilities
+	t.Cleanup(func() { data.ByID, data.ByName, data.AllMoves, data.AllAbilities = byID, byName, moves, abilities })
+
+	pikachu := &data.Pokemon{ID: 25, Name: "pikachu", Abilities: [2]data.AbilityID{9, 0},
+		Moves: []data.VersionedLearnset{{Version: data.GameRuby, Moves: []data.LearnedMove{
+			{MoveID: 85, Method: data.LearnMachine},
+			{MoveID: 98, Method: data.LearnLevelUp},
+		}}}}
+	mime := &data.Pokemon{ID: 122, Name: "mr-mime", Names: data.LocalNames{data.LangEnglish: "Mr. Mime"}}
+	data.ByID = map[uint16]*data.Pokemon{25: pikachu, 122: mime}
+	data.ByName = map[string]*data.Pokemon{"pikachu": pikachu, "mr-mime": mime}
+
+	data.AllMoves = make([]*data.Move, 240)
+	data.AllMoves[85] = &data.Move{ID: 85, Name: "thunderbolt"}
+	data.AllMoves[98] = &data.Move{ID: 98, Name: "quick-attack"}
+	data.AllMoves[57] = &data.Move{ID: 57, Name: "surf"}
+	data.AllMoves[237] = &data.Move{ID: 237, Name: "hidden-power"}
+	data.AllAbilities = make([]*data.Ability, 10)
+	data.AllAbilities[9] = &data.Ability{ID: 9, Name: "static"}
+}
+
+const paste = `=== [gen3] Team ===
+
+Sparky (Pikachu) (M) @ Light Ball
+Ability: Static
+Level: 50
+Shiny: Yes
+EVs: 252 SpA / 4 SpD / 252 Spe
+Timid Nature
+IVs: 0 Atk
+- Thunderbolt
+- Quick Attack
+- Surf
+- Hidden Power [Ice]
+
+Mr. Mime
+Ability: Levitate
+- Splash
+`
+
+func TestParse(t *testing.T) {
+	useFixture(t)
+	sets, problems, err := Parse(strings.NewReader(paste), data.GameRuby)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("got %d sets, want 2", len(sets))
+	}
+	s := sets[0]
+	if s.Pokemon == nil || s.Pokemon.ID != 25 || s.Nickname != "Sparky" || s.Gender != "M" || s.Item != "Light Ball" {
+		t.Errorf("header = %+v", s)
+	}
+	if s.Ability != 9 || s.Level != 50 || !s.Shiny || s.Nature != "Timid" {
+		t.Errorf("ability %d level %d shiny %v nature %q", s.Ability, s.Level, s.Shiny, s.Nature)
+	}
+	if s.EVs.SpecialAttack != 252 || s.EVs.SpecialDefense != 4 || s.EVs.Speed != 252 || s.EVs.HP != 0 {
+		t.Errorf("EVs = %+v", s.EVs)
+	}
+	if !s.HasIVs || s.IVs.Attack != 0 || s.IVs.HP != 31 {
+		t.Errorf("IVs = %+v", s.IVs)
+	}
+	if len(s.Moves) != 4 || s.Moves[1] != 98 || s.Moves[3] != 237 {
+		t.Errorf("Moves = %v", s.Moves)
+	}
+	if sets[1].Pokemon == nil || sets[1].Pokemon.ID != 122 {
+		t.Errorf("Mr. Mime not resolved: %+v", sets[1])
+	}
+
+	want := []string{
+		`line 12: Pikachu can't learn Surf in Ruby`,
+		`line 13: Pikachu can't learn Hidden Power in Ruby`,
+		`line 16: unknown ability "Levitate"`,
+		`line 17: unknown move "Splash"`,
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParse_UnknownSpecies(t *testing.T) {
+	useFixture(t)
+	sets, problems, _ := Parse(strings.NewReader("Missingno @ Leftovers\n- Surf\n"), data.GameRuby)
+	if len(sets) != 1 || sets[0].Species != "Missingno" || sets[0].Pokemon != nil {
+		t.Errorf("sets = %+v", sets)
+	}
+	if len(problems) != 1 || problems[0].Line != 1 {
+		t.Errorf("problems = %v, want one on line 1", problems)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	useFixture(t)
+	sets, _, _ := Parse(strings.NewReader(paste), data.GameRuby)
+	var buf bytes.Buffer
+	if err := Write(&buf, sets[:1]); err != nil {
+		t.Fatal(err)
+	}
+	want := `Sparky (Pikachu) (M) @ Light Ball
+Ability: Static
+Level: 50
+Shiny: Yes
+EVs: 252 SpA / 4 SpD / 252 Spe
+Timid Nature
+IVs: 0 Atk
+- Thunderbolt
+- Quick Attack
+- Surf
+- Hidden Power
+`
+	if buf.String() != want {
+		t.Errorf("Write =\n%s\nwant:\n%s", buf.String(), want)
+	}
+	again, problems, _ := Parse(&buf, data.GameRuby)
+	if len(again) != 1 || len(again[0].Moves) != 4 || again[0].EVs != sets[0].EVs || len(problems) != 2 {
+		t.Errorf("round trip = %+v, problems %v", again, problems)
+	}
+}
+
+func TestWrite_EnglishNames(t *testing.T) {
+	useFixture(t)
+	sets := []Set{{Pokemon: data.ByID[122], Moves: []data.MoveID{98}}}
+	var buf bytes.Buffer
+	if err := Write(&buf, sets); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Mr. Mime\n- Quick Attack\n"; buf.String() != want {
+		t.Errorf("Write = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFromSave(t *testing.T) {
+	useFixture(t)
+	p := &save.Pokemon{Species: 25, Nickname: "PIKACHU", Level: 30, Gen: 3, PID: 10, // Timid
+		Moves: [4]data.MoveID{85}, IVs: save.Stats{HP: 31, Attack: 31, Defense: 31, SpecialAttack: 31, SpecialDefense: 31, Speed: 31}}
+	s := FromSave(p)
+	if s.Nickname != "" || s.Pokemon == nil || s.Level != 30 || s.Nature != "Timid" || s.Ability != 9 {
+		t.Errorf("set = %+v", s)
+	}
+	var buf bytes.Buffer
+	Write(&buf, []Set{s})
+	if !strings.HasPrefix(buf.String(), "Pikachu\nAbility: Static\nLevel: 30\nTimid Nature\n- Thunderbolt\n") {
+		t.Errorf("Write =\n%s", buf.String())
+	}
+}
@@ -0,0 +1,252 @@
+package tracker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/davidlawson7/pokedex/internal/config"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// FileName is the tracker's file inside the config directory.
+const FileName = "tracker.json"
+
+// DefaultProfile is used until the user names a save.
+const DefaultProfile = "default"
+
+// Status is a species' completion state within one save profile.
+type Status byte
+
+const (
+	Unseen Status = 0
+	Seen   Status = 1
+	Caught Status = 2
+)
+
+var statusNames = [3]string{"Unseen", "Seen", "Caught"}
+
+func (s Status) String() string {
+	if int(s) < len(statusNames) {
+		return statusNames[s]
+	}
+	return "Unknown"
+}
+
+// Tracker holds seen/caught state for any number of named save profiles.
+type Tracker struct {
+	path     string
+	active   string
+	profiles map[string]map[uint16]Status
+}
+
+// fileProfile is the on-disk shape of one profile; Caught implies Seen,
+// so a species appears in at most one list.
+type fileProfile struct {
+	Seen   []uint16 `json:"seen"`
+	Caught []uint16 `json:"caught"`
+}
+
+type fileFormat struct {
+	Active   string                 `json:"active"`
+	Profiles map[string]fileProfile `json:"profiles"`
+}
+
+// New returns an empty tracker that saves to path.
+func New(path string) *Tracker {
+	return &Tracker{
+		path:     path,
+		active:   DefaultProfile,
+		profiles: map[string]map[uint16]Status{DefaultProfile: {}},
+	}
+}
+
+// DefaultPath returns the tracker file in the user's config directory.
+func DefaultPath() (string, error) {
+	return config.Path(FileName)
+}
+
+// Load reads the tracker at path. A missing file yields an empty tracker.
+func Load(path string) (*Tracker, error) {
+	t := New(path)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := t.Import(f); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Save writes the tracker to its path atomically.
+func (t *Tracker) Save() error {
+	b, err := json.MarshalIndent(t.file(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(t.path, append(b, '\n'), 0644)
+}
+
+// Export writes every profile as JSON, in the same shape as the tracker file.
+func (t *Tracker) Export(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t.file())
+}
+
+// Import merges profiles from r and adopts its active profile, if any.
+// A species keeps the higher of its two statuses, so importing never forgets a catch.
+func (t *Tracker) Import(r io.Reader) error {
+	var ff fileFormat
+	if err := json.NewDecoder(r).Decode(&ff); err != nil {
+		return err
+	}
+	for name, fp := range ff.Profiles {
+		for _, id := range fp.Seen {
+			t.raise(name, id, Seen)
+		}
+		for _, id := range fp.Caught {
+			t.raise(name, id, Caught)
+		}
+	}
+	if ff.Active != "" {
+		t.SetActive(ff.Active)
+	}
+	return nil
+}
+
+// Merge raises statuses in the named profile from a list of seen and caught IDs,
+// e.g. the Pokédex flags read from a save file.
+func (t *Tracker) Merge(profile string, seen, caught []uint16) {
+	for _, id := range seen {
+		t.raise(profile, id, Seen)
+	}
+	for _, id := range caught {
+		t.raise(profile, id, Caught)
+	}
+}
+
+func (t *Tracker) raise(profile string, id uint16, s Status) {
+	p := t.profile(profile)
+	if s > p[id] {
+		p[id] = s
+	}
+}
+
+func (t *Tracker) profile(name string) map[uint16]Status {
+	p, ok := t.profiles[name]
+	if !ok {
+		p = make(map[uint16]Status)
+		t.profiles[name] = p
+	}
+	return p
+}
+
+func (t *Tracker) file() fileFormat {
+	ff := fileFormat{Active: t.active, Profiles: make(map[string]fileProfile, len(t.profiles))}
+	for name, p := range t.profiles {
+		var fp fileProfile
+		for id, s := range p {
+			switch s {
+			case Seen:
+				fp.Seen = append(fp.Seen, id)
+			case Caught:
+				fp.Caught = append(fp.Caught, id)
+			}
+		}
+		sortIDs(fp.Seen)
+		sortIDs(fp.Caught)
+		ff.Profiles[name] = fp
+	}
+	return ff
+}
+
+func sortIDs(ids []uint16) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+// Active returns the name of the profile that Status, Set and Cycle act on.
+func (t *Tracker) Active() string { return t.active }
+
+// SetActive switches to the named profile, creating it if needed.
+func (t *Tracker) SetActive(name string) {
+	t.profile(name)
+	t.active = name
+}
+
+// Profiles returns the profile names in sorted order.
+func (t *Tracker) Profiles() []string {
+	names := make([]string, 0, len(t.profiles))
+	for name := range t.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Status returns the species' status in the active profile.
+func (t *Tracker) Status(id uint16) Status {
+	return t.profiles[t.active][id]
+}
+
+// Set overrides the species' status in the active profile.
+func (t *Tracker) Set(id uint16, s Status) {
+	p := t.profile(t.active)
+	if s == Unseen {
+		delete(p, id)
+		return
+	}
+	p[id] = s
+}
+
+// Cycle advances Unseen → Seen → Caught → Unseen and returns the new status.
+func (t *Tracker) Cycle(id uint16) Status {
+	next := (t.Status(id) + 1) % 3
+	t.Set(id, next)
+	return next
+}
+
+// Progress counts seen and caught species out of a total.
+// Seen includes caught species, matching the in-game Pokédex.
+type Progress struct {
+	Seen, Caught, Total int
+}
+
+// ByGeneration returns progress for each generation of introduction, indexed 1-3.
+func (t *Tracker) ByGeneration(pokemon []*data.Pokemon) [4]Progress {
+	var out [4]Progress
+	for _, p := range pokemon {
+		t.count(&out[data.IntroducedIn(p.ID)], p.ID)
+	}
+	return out
+}
+
+// ByDex returns progress over the species listed in dex d.
+func (t *Tracker) ByDex(pokemon []*data.Pokemon, d data.Pokedex) Progress {
+	var out Progress
+	for _, p := range pokemon {
+		if p.DexNumber(d) != 0 {
+			t.count(&out, p.ID)
+		}
+	}
+	return out
+}
+
+func (t *Tracker) count(pr *Progress, id uint16) {
+	pr.Total++
+	switch t.Status(id) {
+	case Caught:
+		pr.Caught++
+		pr.Seen++
+	case Seen:
+		pr.Seen++
+	}
+}
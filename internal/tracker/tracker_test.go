@@ -0,0 +1,134 @@
+package tracker
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var fixture = []*data.Pokemon{
+	{ID: 1, Name: "bulbasaur", DexNumbers: [3]uint16{1, 226, 0}},
+	{ID: 25, Name: "pikachu", DexNumbers: [3]uint16{25, 22, 156}},
+	{ID: 152, Name: "chikorita", DexNumbers: [3]uint16{0, 1, 0}},
+	{ID: 252, Name: "treecko", DexNumbers: [3]uint16{0, 0, 1}},
+}
+
+func TestCycle(t *testing.T) {
+	tr := New(filepath.Join(t.TempDir(), FileName))
+	want := []Status{Seen, Caught, Unseen, Seen}
+	for i, w := range want {
+		if got := tr.Cycle(25); got != w {
+			t.Errorf("cycle %d = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestSaveAndLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	tr := New(path)
+	tr.Set(1, Seen)
+	tr.Set(25, Caught)
+	tr.SetActive("crystal")
+	tr.Set(152, Caught)
+	if err := tr.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Active() != "crystal" {
+		t.Errorf("Active = %q, want \"crystal\"", got.Active())
+	}
+	if got.Status(152) != Caught {
+		t.Errorf("crystal Status(152) = %v, want Caught", got.Status(152))
+	}
+	got.SetActive(DefaultProfile)
+	if got.Status(1) != Seen || got.Status(25) != Caught {
+		t.Errorf("default statuses = %v/%v, want Seen/Caught", got.Status(1), got.Status(25))
+	}
+}
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	tr, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Status(1) != Unseen {
+		t.Errorf("Status(1) = %v, want Unseen", tr.Status(1))
+	}
+}
+
+func TestLoad_CorruptFileErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error loading corrupt tracker file")
+	}
+}
+
+func TestImport_NeverDowngrades(t *testing.T) {
+	src := New("")
+	src.Set(25, Seen)
+	src.Set(1, Caught)
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := New("")
+	dst.Set(25, Caught)
+	if err := dst.Import(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Status(25) != Caught {
+		t.Errorf("Status(25) = %v, want Caught (not downgraded)", dst.Status(25))
+	}
+	if dst.Status(1) != Caught {
+		t.Errorf("Status(1) = %v, want Caught (imported)", dst.Status(1))
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tr := New("")
+	tr.Merge("red", []uint16{1, 25}, []uint16{25})
+	tr.SetActive("red")
+	if tr.Status(1) != Seen || tr.Status(25) != Caught {
+		t.Errorf("statuses = %v/%v, want Seen/Caught", tr.Status(1), tr.Status(25))
+	}
+}
+
+func TestByGeneration(t *testing.T) {
+	tr := New("")
+	tr.Set(1, Caught)
+	tr.Set(25, Seen)
+	tr.Set(252, Caught)
+	got := tr.ByGeneration(fixture)
+	if got[1] != (Progress{Seen: 2, Caught: 1, Total: 2}) {
+		t.Errorf("gen 1 = %+v", got[1])
+	}
+	if got[2] != (Progress{Seen: 0, Caught: 0, Total: 1}) {
+		t.Errorf("gen 2 = %+v", got[2])
+	}
+	if got[3] != (Progress{Seen: 1, Caught: 1, Total: 1}) {
+		t.Errorf("gen 3 = %+v", got[3])
+	}
+}
+
+func TestByDex(t *testing.T) {
+	tr := New("")
+	tr.Set(25, Caught)
+	tr.Set(152, Seen)
+	if got := tr.ByDex(fixture, data.DexJohto); got != (Progress{Seen: 2, Caught: 1, Total: 3}) {
+		t.Errorf("Johto = %+v", got)
+	}
+	if got := tr.ByDex(fixture, data.DexHoenn); got != (Progress{Seen: 1, Caught: 1, Total: 2}) {
+		t.Errorf("Hoenn = %+v", got)
+	}
+}
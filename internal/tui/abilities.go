@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/search"
+)
+
+// AbilitiesModel lists every ability with its description, filtered by name.
+type AbilitiesModel struct {
+	input    textinput.Model
+	searcher *search.Searcher // over every ability, built once
+	results  []search.Result
+	cursor   int
+	lang     data.Language // names are shown in this language
+	width    int
+	height   int
+}
+
+// NewAbilitiesModel creates an abilities list in ID order.
+func NewAbilitiesModel(width, height int) AbilitiesModel {
+	ti := textinput.New()
+	ti.Placeholder = "ability name"
+	ti.Focus()
+
+	var abilities []*data.Ability
+	for _, a := range data.AllAbilities {
+		if a != nil {
+			abilities = append(abilities, a)
+		}
+	}
+	c := &search.Corpus{Abilities: abilities}
+	m := AbilitiesModel{input: ti, searcher: search.NewSearcher(c.Index()), width: width, height: height}
+	m.refresh()
+	return m
+}
+
+func (m AbilitiesModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// abilitiesBindings lists the bindings the abilities list matches.
+func abilitiesBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Enter, &k.Escape, &k.Tab, &k.Quit}
+}
+
+func (m AbilitiesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if typed(msg) {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Tab):
+			return m, func() tea.Msg { return switchToTypeChartMsg{} }
+
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Down):
+			if m.cursor < len(m.results)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Enter):
+			if m.cursor < len(m.results) {
+				id := m.results[m.cursor].Ability.ID
+				return m, func() tea.Msg { return switchToAbilityMsg{abilityID: id} }
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.refresh()
+	return m, cmd
+}
+
+// refresh re-filters the list and clamps the cursor.
+func (m *AbilitiesModel) refresh() {
+	m.results = m.searcher.Filter(search.KindAbility, m.input.Value())
+
+	if m.cursor >= len(m.results) {
+		m.cursor = max(len(m.results)-1, 0)
+	}
+}
+
+func (m AbilitiesModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString("  Abilities: ")
+	sb.WriteString(m.input.View())
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)))
+	sb.WriteString("\n")
+
+	if len(m.results) == 0 {
+		sb.WriteString(dimStyle.Render("  No abilities") + "\n")
+	} else {
+		start := 0
+		if m.cursor >= maxVisible {
+			start = m.cursor - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(m.results))
+		for i := start; i < end; i++ {
+			if i == m.cursor {
+				sb.WriteString(selectedRowStyle.Render("  > ") + m.formatRow(m.results[i], selectedRowStyle))
+			} else {
+				sb.WriteString("    " + m.formatRow(m.results[i], lipgloss.NewStyle()))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	help := func(b key.Binding) string { return keyHelp(b, true) }
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("  %s:back  %s:holders  %s%s:navigate  %s:type chart",
+		help(keys.Escape), help(keys.Enter), help(keys.Up), help(keys.Down), help(keys.Tab))))
+	return sb.String()
+}
+
+// formatRow renders an ability's name, with the matched characters
+// highlighted, and as much of its description as fits the screen.
+func (m AbilitiesModel) formatRow(r search.Result, base lipgloss.Style) string {
+	name, positions := resultName(r, m.lang)
+	desc := r.Ability.ShortDesc
+	if m.width > 0 {
+		desc = ansi.Truncate(desc, max(m.width-4-16-2, 10), "…")
+	}
+	return highlight(name, positions, 16, base) + base.Render("  ") + dimStyle.Inherit(base).Render(desc)
+}
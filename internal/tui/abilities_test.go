@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var abilitiesTestAbilities = []*data.Ability{
+	{ID: 5, Name: "sturdy", ShortDesc: "Immune to one-hit KO moves."},
+	{ID: 34, Name: "chlorophyll", ShortDesc: "Doubles Speed in sunlight."},
+	{ID: 65, Name: "overgrow", ShortDesc: "Strengthens Grass moves when HP is low."},
+}
+
+var abilitiesTestPokemon = []*data.Pokemon{
+	{ID: 1, Name: "bulbasaur", Abilities: [2]data.AbilityID{65, 0}, HiddenAbility: 34},
+	{ID: 43, Name: "oddish", Abilities: [2]data.AbilityID{34, 0}},
+	{ID: 74, Name: "geodude", Abilities: [2]data.AbilityID{69, 5}},
+}
+
+func TestAbilitiesModel_ListAndFilter(t *testing.T) {
+	setupAbilitiesForTest(t, abilitiesTestAbilities...)
+	m := NewAbilitiesModel(80, 24)
+	view := ansi.Strip(m.View())
+	for _, want := range []string{
+		"> Sturdy            Immune to one-hit KO moves.",
+		"  Chlorophyll       Doubles Speed in sunlight.",
+		"  Overgrow          Strengthens Grass moves when HP is low.",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in abilities view:\n%s", want, view)
+		}
+	}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("chloro")})
+	m = next.(AbilitiesModel)
+	if len(m.results) != 1 || m.results[0].Ability.ID != 34 {
+		t.Fatalf("\"chloro\" = %d results, want chlorophyll", len(m.results))
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(switchToAbilityMsg); !ok || msg.abilityID != 34 {
+		t.Errorf("enter = %#v, want switchToAbilityMsg for chlorophyll", msg)
+	}
+}
+
+func TestInfoModel_AbilitySlots(t *testing.T) {
+	setupAbilitiesForTest(t, abilitiesTestAbilities...)
+	view := NewAbilityInfoModel(34, abilitiesTestPokemon, data.LangEnglish, 80, 24).View()
+	for _, want := range []string{"Doubles Speed in sunlight.", "#001 Bulbasaur  Hidden", "#043 Oddish  Slot 1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in ability view:\n%s", want, view)
+		}
+	}
+	if view := NewAbilityInfoModel(5, abilitiesTestPokemon, data.LangEnglish, 80, 24).View(); !strings.Contains(view, "#074 Geodude  Slot 2") {
+		t.Errorf("expected geodude in slot 2:\n%s", view)
+	}
+}
+
+func TestAppModel_AbilityHoldersOpenDetail(t *testing.T) {
+	setupAbilitiesForTest(t, abilitiesTestAbilities...)
+	a := NewAppModel()
+	a.search.pokemon = abilitiesTestPokemon
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = map[uint16]*data.Pokemon{1: abilitiesTestPokemon[0]}
+
+	// tab from the search screen goes to moves, then abilities.
+	for range 2 {
+		_, cmd := a.Update(tea.KeyMsg{Type: tea.KeyTab})
+		next, _ := a.Update(cmd())
+		a = next.(AppModel)
+	}
+	if a.current != screenAbilities {
+		t.Fatalf("two tabs reached screen %d, want abilities", a.current)
+	}
+	next, _ := a.Update(tea.KeyMsg{Type: tea.KeyDown})
+	a = next.(AppModel)
+	_, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	next, _ = a.Update(cmd())
+	a = next.(AppModel)
+	if a.current != screenInfo || !strings.Contains(a.View(), "Ability: Chlorophyll") {
+		t.Fatalf("enter opened screen %d:\n%s", a.current, a.View())
+	}
+
+	_, cmd = a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	next, _ = a.Update(cmd())
+	a = next.(AppModel)
+	if a.current != screenDetail || a.detail.pokemon.ID != 1 {
+		t.Errorf("enter on a holder opened screen %d, want bulbasaur's detail", a.current)
+	}
+}
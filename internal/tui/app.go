@@ -1,29 +1,123 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/config"
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+	"github.com/davidlawson7/pokedex/internal/search"
+	"github.com/davidlawson7/pokedex/internal/tracker"
 )
 
 // Messages for screen transitions
 type switchToDetailMsg struct {
-	pokemonID uint16
+	pokemonID  uint16
+	individual *save.Pokemon // set when opened from a save's Pokémon list
 }
 
+// switchToSearchMsg goes to the search screen, forgetting the way there.
 type switchToSearchMsg struct{}
 
+// backMsg returns to the previous screen as it was left, or to search.
+type backMsg struct{}
+
+type switchToPlannerMsg struct{}
+
+type switchToPartyMsg struct{}
+
+type switchToMovesMsg struct{}
+
+type switchToAbilitiesMsg struct{}
+
+type switchToTypeChartMsg struct{}
+
+type switchToMoveMsg struct {
+	moveID data.MoveID
+}
+
+type switchToAbilityMsg struct {
+	abilityID data.AbilityID
+}
+
+type switchToAreaMsg struct {
+	area    string
+	version data.GameVersion // 0 lists the area in every version
+}
+
+type switchToCompareMsg struct {
+	ids []uint16
+}
+
+// setLanguageMsg changes the display language and saves it to the settings.
+type setLanguageMsg struct {
+	lang data.Language
+}
+
+// setVersionMsg makes a game version the one detail and compare screens
+// open on and saves it to the settings.
+type setVersionMsg struct {
+	version data.GameVersion
+}
+
+// setSortMsg changes the search list's sort mode and saves it to the settings.
+type setSortMsg struct {
+	mode search.SortMode
+}
+
 // screen identifies which screen is active.
 type screen int
 
 const (
 	screenSearch screen = iota
 	screenDetail
+	screenPlanner
+	screenParty
+	screenInfo
+	screenCompare
+	screenMoves
+	screenAbilities
+	screenTypeChart
 )
 
+// navEntry is a screen to go back to, as it was when it was left.
+type navEntry struct {
+	screen screen
+	model  tea.Model
+}
+
+// maxHistory caps the navigation stack; the oldest screens are dropped.
+const maxHistory = 50
+
 // AppModel is the root Bubble Tea model that routes between screens.
 type AppModel struct {
-	current screen
-	search  SearchModel
-	detail  DetailModel
+	current   screen
+	history   []navEntry // screens esc goes back to, most recent last
+	search    SearchModel
+	detail    DetailModel
+	planner   PlannerModel
+	party     PartyModel
+	info      InfoModel
+	compare   CompareModel
+	moves     MovesModel
+	abilities AbilitiesModel
+	typeChart TypeChartModel
+	tracker   *tracker.Tracker
+	save      *save.Save
+	// version is the game version detail and compare screens open on; 0
+	// leaves their default.
+	version data.GameVersion
+	// lang is the language screens show names in.
+	lang data.Language
+	// settings are saved to settingsPath when changed; "" disables saving.
+	settings     config.Settings
+	settingsPath string
+	// warning is a problem reading or saving state, shown under the screen
+	// until the next key.
+	warning error
 	width   int
 	height  int
 }
@@ -42,45 +136,319 @@ func (a AppModel) Init() tea.Cmd {
 
 func (a AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		a.warning = nil
+
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
 
 	case switchToDetailMsg:
+		a.push()
 		a.detail = NewDetailModel(msg.pokemonID, a.width, a.height)
+		if a.version != 0 {
+			a.detail.selectedVersion = a.version
+		}
+		a.detail.tracker = a.tracker
+		a.detail.individual = msg.individual
+		a.detail.lang = a.lang
 		a.current = screenDetail
 		return a, a.detail.Init()
 
+	case switchToPlannerMsg:
+		a.push()
+		a.planner = NewPlannerModel(a.width, a.height)
+		a.current = screenPlanner
+		return a, a.planner.Init()
+
+	case switchToPartyMsg:
+		if a.save == nil {
+			return a, nil
+		}
+		a.push()
+		a.party = NewPartyModel(a.save, a.width, a.height)
+		a.party.lang = a.lang
+		a.current = screenParty
+		return a, a.party.Init()
+
+	case switchToMovesMsg:
+		a.push()
+		// The list keeps its query and position between visits.
+		if a.moves.searcher == nil {
+			a.moves = NewMovesModel(a.width, a.height)
+		}
+		a.moves.width, a.moves.height = a.width, a.height
+		a.moves.lang = a.lang
+		a.current = screenMoves
+		return a, a.moves.Init()
+
+	case switchToMoveMsg:
+		return a.showInfo(NewMoveInfoModel(msg.moveID, a.search.pokemon, a.lang, a.width, a.height))
+
+	case switchToAbilitiesMsg:
+		a.push()
+		if a.abilities.searcher == nil {
+			a.abilities = NewAbilitiesModel(a.width, a.height)
+		}
+		a.abilities.width, a.abilities.height = a.width, a.height
+		a.abilities.lang = a.lang
+		a.current = screenAbilities
+		return a, a.abilities.Init()
+
+	case switchToTypeChartMsg:
+		a.push()
+		if a.typeChart.gen == 0 {
+			a.typeChart = NewTypeChartModel(a.width, a.height)
+		}
+		a.typeChart.width, a.typeChart.height = a.width, a.height
+		a.current = screenTypeChart
+		return a, a.typeChart.Init()
+
+	case switchToAbilityMsg:
+		return a.showInfo(NewAbilityInfoModel(msg.abilityID, a.search.pokemon, a.lang, a.width, a.height))
+
+	case switchToAreaMsg:
+		return a.showInfo(NewAreaInfoModel(msg.area, msg.version, a.search.pokemon, a.lang, a.width, a.height))
+
+	case switchToCompareMsg:
+		a.push()
+		a.compare = NewCompareModel(msg.ids, a.width, a.height)
+		a.compare.lang = a.lang
+		if a.version != 0 {
+			a.compare.selectedVersion = a.version
+		}
+		a.current = screenCompare
+		return a, a.compare.Init()
+
 	case switchToSearchMsg:
+		a.history = nil
 		a.current = screenSearch
 		return a, nil
+
+	case backMsg:
+		if len(a.history) == 0 {
+			a.current = screenSearch
+			return a, nil
+		}
+		e := a.history[len(a.history)-1]
+		a.history = a.history[:len(a.history)-1]
+		// The screen may have been left at another window size.
+		m, _ := e.model.Update(tea.WindowSizeMsg{Width: a.width, Height: a.height})
+		a.setModel(e.screen, m)
+		a.current = e.screen
+		return a, nil
+
+	case setLanguageMsg:
+		a.lang = msg.lang
+		a.search.lang = msg.lang
+		a.settings.Language = msg.lang.Code()
+		a.search.refresh() // names sort in the display language
+		a.saveSettings()
+		return a, nil
+
+	case setVersionMsg:
+		a.version = msg.version
+		a.settings.Version = msg.version.String()
+		a.saveSettings()
+		return a, nil
+
+	case setSortMsg:
+		a.search.sort = msg.mode
+		a.search.refresh()
+		a.settings.Sort = msg.mode.Code()
+		a.saveSettings()
+		return a, nil
 	}
 
+	m, cmd := a.model().Update(msg)
+	a.setModel(a.current, m)
+	return a, cmd
+}
+
+// model returns the current screen's model.
+func (a AppModel) model() tea.Model {
 	switch a.current {
-	case screenSearch:
-		m, cmd := a.search.Update(msg)
-		a.search = m.(SearchModel)
-		return a, cmd
 	case screenDetail:
-		m, cmd := a.detail.Update(msg)
-		a.detail = m.(DetailModel)
-		return a, cmd
+		return a.detail
+	case screenPlanner:
+		return a.planner
+	case screenParty:
+		return a.party
+	case screenInfo:
+		return a.info
+	case screenCompare:
+		return a.compare
+	case screenMoves:
+		return a.moves
+	case screenAbilities:
+		return a.abilities
+	case screenTypeChart:
+		return a.typeChart
 	}
-	return a, nil
+	return a.search
 }
 
-func (a AppModel) View() string {
-	switch a.current {
+// setModel stores m as the model of screen s.
+func (a *AppModel) setModel(s screen, m tea.Model) {
+	switch s {
 	case screenDetail:
-		return a.detail.View()
+		a.detail = m.(DetailModel)
+	case screenPlanner:
+		a.planner = m.(PlannerModel)
+	case screenParty:
+		a.party = m.(PartyModel)
+	case screenInfo:
+		a.info = m.(InfoModel)
+	case screenCompare:
+		a.compare = m.(CompareModel)
+	case screenMoves:
+		a.moves = m.(MovesModel)
+	case screenAbilities:
+		a.abilities = m.(AbilitiesModel)
+	case screenTypeChart:
+		a.typeChart = m.(TypeChartModel)
 	default:
-		return a.search.View()
+		a.search = m.(SearchModel)
+	}
+}
+
+// push saves the current screen so a backMsg can return to it.
+func (a *AppModel) push() {
+	a.history = append(a.history, navEntry{a.current, a.model()})
+	if len(a.history) > maxHistory {
+		a.history = a.history[1:]
 	}
 }
 
+// saveSettings writes the settings, reporting a failure as the warning.
+func (a *AppModel) saveSettings() {
+	if a.settingsPath != "" {
+		a.warning = config.SaveSettings(a.settingsPath, a.settings)
+	}
+}
+
+func (a AppModel) showInfo(m InfoModel) (tea.Model, tea.Cmd) {
+	a.push()
+	a.info = m
+	a.current = screenInfo
+	return a, a.info.Init()
+}
+
+func (a AppModel) View() string {
+	if a.warning != nil {
+		return a.model().View() + "\n" + errorStyle.Render("⚠ "+a.warning.Error())
+	}
+	return a.model().View()
+}
+
+// Options configures Run.
+type Options struct {
+	// Save, if set, prefills the tracker profile SaveName from its Pokédex
+	// flags and enables the "my Pokémon" screen.
+	Save     *save.Save
+	SaveName string
+	// Individual, if set, opens the detail screen for a Pokémon read from a
+	// single-Pokémon file.
+	Individual *save.Pokemon
+	// Language, if set, overrides the saved display language for this
+	// session; it takes any name or code data.ParseLanguage accepts.
+	Language string
+}
+
 // Run starts the Bubble Tea application.
-func Run() error {
-	p := tea.NewProgram(NewAppModel(), tea.WithAltScreen())
-	_, err := p.Run()
+func Run(opts Options) error {
+	path, err := tracker.DefaultPath()
+	if err != nil {
+		return err
+	}
+	// Files that can't be read shouldn't keep the app from starting; the
+	// next change saves over them.
+	var warnings []error
+	t, err := tracker.Load(path)
+	if err != nil {
+		warnings = append(warnings, fmt.Errorf("%w; starting with an empty tracker", err))
+		t = tracker.New(path)
+	}
+	if opts.Save != nil {
+		t.Merge(opts.SaveName, opts.Save.Seen, opts.Save.Owned)
+		t.SetActive(opts.SaveName)
+		if err := t.Save(); err != nil {
+			return err
+		}
+	}
+
+	settingsPath, err := config.SettingsPath()
+	if err != nil {
+		return err
+	}
+	settings, err := config.LoadSettings(settingsPath)
+	if err != nil {
+		warnings = append(warnings, fmt.Errorf("%w; using the default settings", err))
+		settings = config.Settings{}
+	}
+	lang, _ := data.ParseLanguage(settings.Language)
+	if opts.Language != "" {
+		var ok bool
+		if lang, ok = data.ParseLanguage(opts.Language); !ok {
+			return fmt.Errorf("unknown language %q", opts.Language)
+		}
+	}
+
+	keysPath, err := config.KeysPath()
+	if err != nil {
+		return err
+	}
+	keysCfg, err := config.LoadKeys(keysPath)
+	if err != nil {
+		return err
+	}
+	if keys, err = NewKeyMap(keysCfg); err != nil {
+		return fmt.Errorf("%s: %w", keysPath, err)
+	}
+
+	themePath, err := config.ThemePath()
+	if err != nil {
+		return err
+	}
+	themeCfg, err := config.LoadTheme(themePath)
+	if err != nil {
+		return err
+	}
+	theme, err := NewTheme(noColor(themeCfg, os.Getenv("NO_COLOR") != ""))
+	if err != nil {
+		return fmt.Errorf("%s: %w", themePath, err)
+	}
+	applyTheme(theme)
+
+	app := NewAppModel()
+	app.lang = lang
+	app.search.lang = lang
+	if mode, ok := search.ParseSortMode(settings.Sort); ok {
+		app.search.sort = mode
+	}
+	app.search.refresh() // names sort in the display language
+	if v, ok := data.ParseGameVersion(settings.Version); ok {
+		app.version = v
+	}
+	app.settings = settings
+	app.settingsPath = settingsPath
+	app.warning = errors.Join(warnings...)
+	app.tracker = t
+	app.search.tracker = t
+	app.save = opts.Save
+	app.search.hasSave = opts.Save != nil
+	if opts.Individual != nil {
+		app.detail = NewDetailModel(opts.Individual.Species, 0, 0)
+		if app.version != 0 {
+			app.detail.selectedVersion = app.version
+		}
+		app.detail.tracker = t
+		app.detail.individual = opts.Individual
+		app.detail.lang = lang
+		app.current = screenDetail
+	}
+
+	p := tea.NewProgram(app, tea.WithAltScreen())
+	_, err = p.Run()
 	return err
 }
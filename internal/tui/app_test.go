@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/config"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// send passes msg to a, then each message its commands produce, the way the
+// program loop would for screen switches.
+func send(a AppModel, msg tea.Msg) AppModel {
+	next, cmd := a.Update(msg)
+	a = next.(AppModel)
+	if cmd != nil {
+		switch m := cmd().(type) {
+		case switchToDetailMsg, switchToMoveMsg, switchToAbilityMsg, switchToAreaMsg, switchToMovesMsg,
+			switchToAbilitiesMsg, switchToTypeChartMsg, switchToSearchMsg, backMsg, setVersionMsg:
+			a = send(a, m)
+		}
+	}
+	return a
+}
+
+func TestAppModel_BackRetracesSteps(t *testing.T) {
+	setupMovesForTest(t, infoTestSurf)
+	setupAbilitiesForTest(t, infoTestLevitate)
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = map[uint16]*data.Pokemon{92: infoTestPokemon[0], 131: infoTestPokemon[1]}
+	a := NewAppModel()
+	a.search.pokemon = infoTestPokemon
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	// search → Surf → Lapras, left on its Locations tab → Levitate → Gastly.
+	a = send(a, switchToMoveMsg{moveID: 57})
+	a = send(a, tea.KeyMsg{Type: tea.KeyEnter})
+	if a.current != screenDetail || a.detail.pokemon.ID != 131 {
+		t.Fatalf("enter on Surf's learner opened screen %d", a.current)
+	}
+	a.detail.activeTab = tabLocations
+	a = send(a, switchToAbilityMsg{abilityID: 26})
+	a = send(a, tea.KeyMsg{Type: tea.KeyEnter})
+	if a.current != screenDetail || a.detail.pokemon.ID != 92 {
+		t.Fatalf("enter on Levitate's holder opened screen %d", a.current)
+	}
+
+	steps := []struct {
+		screen screen
+		check  func(AppModel) bool
+	}{
+		{screenInfo, func(a AppModel) bool { return a.info.title == "Ability: Levitate" }},
+		{screenDetail, func(a AppModel) bool { return a.detail.pokemon.ID == 131 && a.detail.activeTab == tabLocations }},
+		{screenInfo, func(a AppModel) bool { return a.info.title == "Move: Surf" }},
+		{screenSearch, func(AppModel) bool { return true }},
+		{screenSearch, func(AppModel) bool { return true }},
+	}
+	for i, st := range steps {
+		a = send(a, esc)
+		if a.current != st.screen || !st.check(a) {
+			t.Fatalf("esc %d went to screen %d, want %d", i+1, a.current, st.screen)
+		}
+	}
+}
+
+func TestAppModel_SearchForgetsHistory(t *testing.T) {
+	a := NewAppModel()
+	a = send(a, switchToMovesMsg{})
+	a = send(a, switchToTypeChartMsg{})
+	if len(a.history) != 2 {
+		t.Fatalf("history = %d screens, want 2", len(a.history))
+	}
+	a = send(a, tea.KeyMsg{Type: tea.KeyTab})
+	if a.current != screenSearch || len(a.history) != 0 {
+		t.Errorf("tab from the type chart: screen %d, %d in history", a.current, len(a.history))
+	}
+	for range maxHistory + 10 {
+		a = send(a, switchToMovesMsg{})
+	}
+	if len(a.history) != maxHistory {
+		t.Errorf("history = %d screens, want the cap of %d", len(a.history), maxHistory)
+	}
+}
+
+func TestAppModel_PickedVersionIsRemembered(t *testing.T) {
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = map[uint16]*data.Pokemon{92: infoTestPokemon[0], 131: infoTestPokemon[1]}
+	a := NewAppModel()
+	a.settingsPath = filepath.Join(t.TempDir(), config.SettingsFileName)
+
+	a = send(a, switchToDetailMsg{pokemonID: 92})
+	a = send(a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	for range data.GameFireRed - data.GameRed {
+		a = send(a, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	a = send(a, tea.KeyMsg{Type: tea.KeyEnter})
+	if a.detail.selectedVersion != data.GameFireRed || a.detail.picker.open {
+		t.Fatalf("picked %v, picker open %v; want FireRed and closed", a.detail.selectedVersion, a.detail.picker.open)
+	}
+
+	a = send(a, switchToDetailMsg{pokemonID: 131})
+	if a.detail.selectedVersion != data.GameFireRed {
+		t.Errorf("next detail opened on %v, want FireRed", a.detail.selectedVersion)
+	}
+	s, err := config.LoadSettings(a.settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Version != "FireRed" {
+		t.Errorf("saved Version = %q, want \"FireRed\"", s.Version)
+	}
+}
+
+func TestAppModel_WarningShowsUntilKey(t *testing.T) {
+	a := NewAppModel()
+	a.warning = errors.New("reading tracker.json: bad")
+	if v := a.View(); !strings.Contains(v, "⚠ reading tracker.json: bad") {
+		t.Errorf("warning not shown:\n%s", v)
+	}
+	a = send(a, tea.KeyMsg{Type: tea.KeyDown})
+	if a.warning != nil || strings.Contains(a.View(), "⚠ reading") {
+		t.Error("warning still shown after a key")
+	}
+}
+
+func TestAppModel_SettingsSaveErrorIsAWarning(t *testing.T) {
+	a := NewAppModel()
+	a.settingsPath = t.TempDir() // a directory, so saving fails
+	a = send(a, setVersionMsg{version: data.GameCrystal})
+	if a.warning == nil {
+		t.Error("failed settings save left no warning")
+	}
+	if a.search.err != nil {
+		t.Errorf("settings error clobbered the search footer: %v", a.search.err)
+	}
+}
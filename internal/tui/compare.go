@@ -0,0 +1,282 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// maxCompare is how many Pokémon the compare screen holds.
+const maxCompare = 4
+
+// compareColumn is the width of each Pokémon's column.
+const compareColumn = 16
+
+// CompareModel shows two to four Pokémon side by side: base stats with the
+// best in each highlighted, types and abilities in the selected version's
+// generation, and the moves each learns in that version that the others
+// don't.
+type CompareModel struct {
+	pokemon         []*data.Pokemon
+	selectedVersion data.GameVersion
+	picker          versionPicker
+	moveScroll      int
+	lang            data.Language // names are shown in this language
+	width           int
+	height          int
+}
+
+// NewCompareModel creates a compare screen for the given Pokémon IDs,
+// skipping any that aren't loaded.
+func NewCompareModel(ids []uint16, width, height int) CompareModel {
+	m := CompareModel{selectedVersion: data.GameRed, width: width, height: height}
+	for _, id := range ids {
+		if p := data.ByID[id]; p != nil {
+			m.pokemon = append(m.pokemon, p)
+		}
+	}
+	return m
+}
+
+func (m CompareModel) Init() tea.Cmd { return nil }
+
+// compareBindings lists the bindings the compare screen, outside the version picker, matches.
+func compareBindings(k *KeyMap) []*key.Binding {
+	bs := []*key.Binding{&k.Up, &k.Down, &k.Escape, &k.Version}
+	for i := range k.VersionKeys {
+		bs = append(bs, &k.VersionKeys[i])
+	}
+	return bs
+}
+
+func (m CompareModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		if m.picker.open {
+			if v := m.picker.update(msg); v != 0 {
+				m.setVersion(v)
+				return m, func() tea.Msg { return setVersionMsg{version: v} }
+			}
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Version):
+			m.picker.show(m.selectedVersion)
+
+		case key.Matches(msg, keys.Up):
+			if m.moveScroll > 0 {
+				m.moveScroll--
+			}
+
+		case key.Matches(msg, keys.Down):
+			columns, _ := m.exclusiveMoves()
+			if m.moveScroll < moveRows(columns)-1 {
+				m.moveScroll++
+			}
+
+		default:
+			// Version keys 1-9 map to GameVersion constants; only a version
+			// chosen in the picker is kept for later screens.
+			if n := numberKey(msg, keys.VersionKeys[:]); n != 0 {
+				m.setVersion(data.GameVersion(n))
+			}
+		}
+	}
+	return m, nil
+}
+
+// setVersion shows version v, scrolling the moves back to the top.
+func (m *CompareModel) setVersion(v data.GameVersion) {
+	m.selectedVersion = v
+	m.moveScroll = 0
+}
+
+func (m CompareModel) View() string {
+	if len(m.pokemon) < 2 {
+		return "  Mark at least two Pokémon to compare (ctrl+t on the search screen).\n\n" +
+			footerStyle.Render("  esc:back")
+	}
+	gen := data.GenForVersion(m.selectedVersion)
+	rule := strings.Repeat("─", max(m.width-2, 40)) + "\n"
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  Compare  ver: %s (Gen %d)\n", m.selectedVersion, gen))
+	sb.WriteString(rule)
+	if m.picker.open {
+		return sb.String() + m.picker.view(nil, m.selectedVersion) + "\n"
+	}
+
+	sb.WriteString(m.row("", func(_ int, p *data.Pokemon) string {
+		return headerStyle.Render(padRight(fmt.Sprintf("#%03d %s", p.ID, pokemonName(p, m.lang)), compareColumn))
+	}))
+	sb.WriteString(m.row("Type", func(_ int, p *data.Pokemon) string {
+		var badges []string
+		for _, t := range p.TypesForGen(gen) {
+			if t != data.TypeNone {
+				badges = append(badges, TypeBadge(t.String()))
+			}
+		}
+		return padRight(strings.Join(badges, " "), compareColumn)
+	}))
+	if gen >= 3 {
+		for slot := range 2 {
+			label := ""
+			if slot == 0 {
+				label = "Ability"
+			}
+			sb.WriteString(m.row(label, func(_ int, p *data.Pokemon) string {
+				return padRight(abilityName(p.Abilities[slot], m.lang), compareColumn)
+			}))
+		}
+	} else {
+		sb.WriteString(fmt.Sprintf("  %-8s %s\n", "Ability", dimStyle.Render("(introduced in Gen 3)")))
+	}
+	sb.WriteString(rule)
+
+	type compareStat struct {
+		label string
+		val   func(s data.BaseStats) int
+	}
+	stats := []compareStat{
+		{"HP", func(s data.BaseStats) int { return int(s.HP) }},
+		{"Atk", func(s data.BaseStats) int { return int(s.Attack) }},
+		{"Def", func(s data.BaseStats) int { return int(s.Defense) }},
+		{"SpAtk", func(s data.BaseStats) int { return int(s.SpecialAttack) }},
+		{"SpDef", func(s data.BaseStats) int { return int(s.SpecialDefense) }},
+		{"Speed", func(s data.BaseStats) int { return int(s.Speed) }},
+	}
+	if gen < 2 {
+		// Gen 1 has a single Special stat, which became Sp. Atk.
+		stats = append(stats[:3:3], compareStat{"Special", stats[3].val}, stats[5])
+	}
+	base := stats
+	stats = append(stats[:len(base):len(base)], compareStat{"Total", func(s data.BaseStats) int {
+		total := 0
+		for _, st := range base {
+			total += st.val(s)
+		}
+		return total
+	}})
+	for _, st := range stats {
+		best := 0
+		for _, p := range m.pokemon {
+			best = max(best, st.val(p.Stats))
+		}
+		sb.WriteString(m.row(st.label, func(_ int, p *data.Pokemon) string {
+			v := st.val(p.Stats)
+			if v == best {
+				return matchStyle.Render(padRight(fmt.Sprintf("%3d ★", v), compareColumn))
+			}
+			return padRight(fmt.Sprintf("%3d", v), compareColumn)
+		}))
+	}
+	sb.WriteString(rule)
+	sb.WriteString(m.renderExclusiveMoves())
+
+	sb.WriteString("\n")
+	help := func(b key.Binding) string { return keyHelp(b, false) }
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("  %s:back  %s/%s:version  %s%s:scroll moves",
+		help(keys.Escape), help(keys.Version), numberKeysHelp(keys.VersionKeys[:]), help(keys.Up), help(keys.Down))))
+	return sb.String()
+}
+
+// row renders a labelled line with one cell per Pokémon.
+func (m CompareModel) row(label string, cell func(i int, p *data.Pokemon) string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  %-8s ", label))
+	for i, p := range m.pokemon {
+		sb.WriteString(cell(i, p) + " ")
+	}
+	return strings.TrimRight(sb.String(), " ") + "\n"
+}
+
+// exclusiveMoves returns, for each Pokémon, the sorted names of the moves it
+// learns in the selected version by any method that none of the others do.
+// noData is set when the version has no learnsets for any of them.
+func (m CompareModel) exclusiveMoves() (columns [][]string, noData bool) {
+	learned := make([]map[data.MoveID]bool, len(m.pokemon))
+	noData = true
+	for i, p := range m.pokemon {
+		learned[i] = make(map[data.MoveID]bool)
+		for _, vls := range p.Moves {
+			if vls.Version != m.selectedVersion {
+				continue
+			}
+			noData = false
+			for _, lm := range vls.Moves {
+				learned[i][lm.MoveID] = true
+			}
+		}
+	}
+	if noData {
+		return nil, true
+	}
+
+	columns = make([][]string, len(m.pokemon))
+	for i := range m.pokemon {
+		for id := range learned[i] {
+			mv := data.MoveByID(id)
+			if mv == nil || learnedByOthers(learned, i, id) {
+				continue
+			}
+			columns[i] = append(columns[i], moveName(mv, m.lang))
+		}
+		sort.Strings(columns[i])
+	}
+	return columns, false
+}
+
+// moveRows is the number of lines the longest column of moves takes.
+func moveRows(columns [][]string) int {
+	rows := 0
+	for _, c := range columns {
+		rows = max(rows, len(c))
+	}
+	return rows
+}
+
+// renderExclusiveMoves lists, under each Pokémon, the moves only it learns.
+func (m CompareModel) renderExclusiveMoves() string {
+	columns, noData := m.exclusiveMoves()
+	if noData {
+		return dimStyle.Render("  No move data for this version") + "\n"
+	}
+	rows := moveRows(columns)
+	if rows == 0 {
+		return dimStyle.Render("  No moves unique to one of them in this version") + "\n"
+	}
+	var sb strings.Builder
+	sb.WriteString(headerStyle.Render("  Only learned by this one:") + "\n")
+	start := min(m.moveScroll, rows-1)
+	end := min(start+maxVisible, rows)
+	for r := start; r < end; r++ {
+		sb.WriteString(m.row("", func(i int, _ *data.Pokemon) string {
+			if r < len(columns[i]) {
+				return padRight(columns[i][r], compareColumn)
+			}
+			return strings.Repeat(" ", compareColumn)
+		}))
+	}
+	return sb.String()
+}
+
+// learnedByOthers reports whether any set but learned[i] has id.
+func learnedByOthers(learned []map[data.MoveID]bool, i int, id data.MoveID) bool {
+	for j := range learned {
+		if j != i && learned[j][id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var compareTestMoves = []*data.Move{
+	{ID: 24, Name: "Double Kick"},
+	{ID: 85, Name: "Thunderbolt"},
+	{ID: 97, Name: "Agility"},
+	{ID: 42, Name: "Pin Missile"},
+	{ID: 49, Name: "Sonic Boom"},
+}
+
+// redLearnset is a Red learnset of the given moves.
+func redLearnset(ids ...data.MoveID) []data.VersionedLearnset {
+	var lms []data.LearnedMove
+	for _, id := range ids {
+		lms = append(lms, data.LearnedMove{MoveID: id})
+	}
+	return []data.VersionedLearnset{{Version: data.GameRed, Moves: lms}}
+}
+
+var compareTestPokemon = map[uint16]*data.Pokemon{
+	135: {ID: 135, Name: "jolteon", Types: [2]data.PokeType{data.TypeElectric, data.TypeNone},
+		Stats: data.BaseStats{HP: 65, Attack: 65, Defense: 60, SpecialAttack: 110, SpecialDefense: 95, Speed: 130},
+		Moves: redLearnset(24, 42, 85, 97)},
+	101: {ID: 101, Name: "electrode", Types: [2]data.PokeType{data.TypeElectric, data.TypeNone},
+		Stats: data.BaseStats{HP: 60, Attack: 50, Defense: 70, SpecialAttack: 80, SpecialDefense: 80, Speed: 140},
+		Moves: redLearnset(49, 85, 97)},
+}
+
+func TestCompareModel_View(t *testing.T) {
+	setupMovesForTest(t, compareTestMoves...)
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = compareTestPokemon
+	view := ansi.Strip(NewCompareModel([]uint16{135, 101}, 80, 24).View())
+	for _, want := range []string{
+		"#135 Jolteon     #101 Electrode",
+		"Speed    130              140 ★",
+		"HP        65 ★             60",
+		"Special  110 ★             80",
+		"Total    430 ★            400",
+		"Double Kick      Sonic Boom",
+		"Pin Missile",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in compare view:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Thunderbolt") || strings.Contains(view, "Agility") {
+		t.Errorf("moves both learn should be left out:\n%s", view)
+	}
+	if strings.Contains(view, "SpAtk") || strings.Contains(view, "SpDef") {
+		t.Errorf("expected one Special row in Red:\n%s", view)
+	}
+	if !strings.Contains(view, "(introduced in Gen 3)") {
+		t.Errorf("expected no abilities in Red:\n%s", view)
+	}
+}
+
+func TestCompareModel_MoveScrollStopsAtLastRow(t *testing.T) {
+	setupMovesForTest(t, compareTestMoves...)
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = compareTestPokemon
+	var model tea.Model = NewCompareModel([]uint16{135, 101}, 80, 24)
+	for range 5 {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	// Jolteon has two moves of its own, so there's one row to scroll past.
+	if got := model.(CompareModel).moveScroll; got != 1 {
+		t.Fatalf("moveScroll = %d after overscrolling, want 1", got)
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := model.(CompareModel).moveScroll; got != 0 {
+		t.Errorf("moveScroll = %d after one up, want 0", got)
+	}
+}
+
+func TestCompareModel_VersionPicker(t *testing.T) {
+	setupMovesForTest(t, compareTestMoves...)
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = compareTestPokemon
+	var model tea.Model = NewCompareModel([]uint16{135, 101}, 80, 24)
+
+	// A number key shows a version without making it the preferred one.
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+	if got := model.(CompareModel).selectedVersion; got != data.GameGold || cmd != nil {
+		t.Fatalf("4 showed %v with command %v, want Gold and nothing saved", got, cmd)
+	}
+
+	// The picker reaches the versions past 9 and keeps the one chosen.
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if view := ansi.Strip(model.View()); !strings.Contains(view, "LeafGreen") {
+		t.Fatalf("expected the version picker:\n%s", view)
+	}
+	for range 10 {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.(CompareModel).selectedVersion; got != data.GameLeafGreen {
+		t.Errorf("picked %v, want LeafGreen", got)
+	}
+	if msg, ok := cmd().(setVersionMsg); !ok || msg.version != data.GameLeafGreen {
+		t.Errorf("enter = %#v, want setVersionMsg for LeafGreen", msg)
+	}
+}
+
+func TestAppModel_CompareStartsOnPreferredVersion(t *testing.T) {
+	setupMovesForTest(t, compareTestMoves...)
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = compareTestPokemon
+	a := NewAppModel()
+	a.version = data.GameFireRed
+	a = send(a, switchToCompareMsg{ids: []uint16{135, 101}})
+	if a.compare.selectedVersion != data.GameFireRed {
+		t.Errorf("compare opened on %v, want the preferred FireRed", a.compare.selectedVersion)
+	}
+}
+
+func TestSearchModel_MarkAndCompare(t *testing.T) {
+	m := newTestSearchModel()
+	m.pokemon = testPokemon
+	mark := tea.KeyMsg{Type: tea.KeyCtrlT}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	next, _ := m.Update(mark)
+	m = next.(SearchModel)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlG}); cmd != nil {
+		t.Error("ctrl+g with one Pokémon marked should do nothing")
+	}
+	for _, k := range []tea.KeyMsg{down, mark, down, mark, down, mark, down, mark} {
+		next, _ = m.Update(k)
+		m = next.(SearchModel)
+	}
+	if len(m.compare) != maxCompare {
+		t.Fatalf("marked %v, want the first %d", m.compare, maxCompare)
+	}
+	if !strings.Contains(m.View(), "   +  #004 Charmander") {
+		t.Errorf("expected a mark on charmander's row:\n%s", m.View())
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if cmd == nil {
+		t.Fatal("ctrl+g returned no command")
+	}
+	msg, ok := cmd().(switchToCompareMsg)
+	if !ok || len(msg.ids) != 4 || msg.ids[0] != 4 || msg.ids[3] != 152 {
+		t.Errorf("ctrl+g = %#v, want the four marked IDs in order", msg)
+	}
+
+	// Marking again unmarks.
+	m.cursor = 0
+	next, _ = m.Update(mark)
+	if got := next.(SearchModel).compare; len(got) != 3 || got[0] != 5 {
+		t.Errorf("after unmarking charmander = %v", got)
+	}
+}
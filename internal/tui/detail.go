@@ -2,11 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"unicode"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+	"github.com/davidlawson7/pokedex/internal/tracker"
 )
 
 // tabIndex names the three detail tabs.
@@ -25,8 +29,16 @@ type DetailModel struct {
 	pokemon         *data.Pokemon
 	activeTab       tabIndex
 	selectedVersion data.GameVersion
-	moveScroll      int
-	locationScroll  int
+	moveCursor      int           // row in the Moves tab
+	hiddenMethods   [4]bool       // Moves tab sections hidden, by LearnMethod
+	moveType        data.PokeType // Moves tab type filter; TypeNone shows all
+	moveCategory    int           // Moves tab category filter; 0 shows all, else MoveCategory+1
+	locationCursor  int           // row in the Locations tab
+	picker          versionPicker
+	tracker         *tracker.Tracker // nil disables seen/caught marking
+	individual      *save.Pokemon    // a Pokémon from a save; adds its actual stats
+	err             error            // last tracker save error, shown in the footer
+	lang            data.Language    // names are shown in this language
 	width           int
 	height          int
 }
@@ -46,6 +58,17 @@ func NewDetailModel(pokemonID uint16, width, height int) DetailModel {
 
 func (m DetailModel) Init() tea.Cmd { return nil }
 
+// detailBindings lists the bindings the detail screen, outside the version picker, matches.
+func detailBindings(k *KeyMap) []*key.Binding {
+	bs := []*key.Binding{&k.Up, &k.Down, &k.Left, &k.Right, &k.Enter, &k.Escape, &k.Tab, &k.ShiftTab,
+		&k.Track, &k.Version, &k.TypeFilter, &k.CategoryFilter, &k.HideLevelUp, &k.HideMachines, &k.HideTutor,
+		&k.HideEgg}
+	for i := range k.VersionKeys {
+		bs = append(bs, &k.VersionKeys[i])
+	}
+	return bs
+}
+
 func (m DetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -53,56 +76,259 @@ func (m DetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 	case tea.KeyMsg:
+		if m.picker.open {
+			if v := m.picker.update(msg); v != 0 {
+				return m, m.pickVersion(v)
+			}
+			return m, nil
+		}
 		switch {
-		case msg.Type == tea.KeyEsc:
-			return m, func() tea.Msg { return switchToSearchMsg{} }
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Track):
+			if m.tracker != nil && m.pokemon != nil {
+				m.tracker.Cycle(m.pokemon.ID)
+				m.err = m.tracker.Save()
+			}
+
+		case key.Matches(msg, keys.Left):
+			m.step(-1)
 
-		case msg.Type == tea.KeyTab:
+		case key.Matches(msg, keys.Right):
+			m.step(1)
+
+		case key.Matches(msg, keys.Tab):
 			m.activeTab = (m.activeTab + 1) % 3
-			m.moveScroll = 0
-			m.locationScroll = 0
+			m.moveCursor = 0
+			m.locationCursor = 0
 
-		case msg.Type == tea.KeyShiftTab:
+		case key.Matches(msg, keys.ShiftTab):
 			m.activeTab = (m.activeTab + 2) % 3
-			m.moveScroll = 0
-			m.locationScroll = 0
+			m.moveCursor = 0
+			m.locationCursor = 0
 
-		case msg.Type == tea.KeyUp:
+		case key.Matches(msg, keys.Up):
 			switch m.activeTab {
 			case tabMoves:
-				if m.moveScroll > 0 {
-					m.moveScroll--
+				if m.moveCursor > 0 {
+					m.moveCursor--
 				}
 			case tabLocations:
-				if m.locationScroll > 0 {
-					m.locationScroll--
+				if m.locationCursor > 0 {
+					m.locationCursor--
 				}
 			}
 
-		case msg.Type == tea.KeyDown:
+		case key.Matches(msg, keys.Down):
 			switch m.activeTab {
 			case tabMoves:
-				m.moveScroll++
+				if m.moveCursor < len(m.versionMoves())-1 {
+					m.moveCursor++
+				}
 			case tabLocations:
-				m.locationScroll++
+				if m.locationCursor < len(m.versionLocations())-1 {
+					m.locationCursor++
+				}
 			}
 
-		case msg.Type == tea.KeyRunes:
-			// Version keys 1-9 map to GameVersion constants
-			if len(msg.Runes) == 1 {
-				r := msg.Runes[0]
-				if r >= '1' && r <= '9' {
-					v := data.GameVersion(r - '0')
-					if v <= data.GameLeafGreen {
-						m.selectedVersion = v
-					}
+		case key.Matches(msg, keys.Enter):
+			switch m.activeTab {
+			case tabMoves:
+				if moves := m.versionMoves(); m.moveCursor < len(moves) {
+					id := moves[m.moveCursor].MoveID
+					return m, func() tea.Msg { return switchToMoveMsg{moveID: id} }
+				}
+			case tabLocations:
+				if locs := m.versionLocations(); m.locationCursor < len(locs) {
+					area, version := locs[m.locationCursor].AreaName, m.selectedVersion
+					return m, func() tea.Msg { return switchToAreaMsg{area: area, version: version} }
 				}
 			}
+
+		case key.Matches(msg, keys.Version):
+			m.picker.show(m.selectedVersion)
+
+		case m.activeTab == tabMoves && m.filterMoves(msg):
+
+		default:
+			// Version keys 1-9 map to GameVersion constants; only a version
+			// chosen in the picker is kept for later screens.
+			if n := numberKey(msg, keys.VersionKeys[:]); n != 0 {
+				m.setVersion(data.GameVersion(n))
+			}
 		}
 	}
 	return m, nil
 }
 
+// setVersion shows version v on this screen.
+func (m *DetailModel) setVersion(v data.GameVersion) {
+	m.selectedVersion = v
+	if !typeInGen(m.moveType, data.GenForVersion(v)) {
+		m.moveType = data.TypeNone
+	}
+	m.clampCursors()
+}
+
+// pickVersion shows version v, chosen in the picker, and returns a command
+// making it the preferred version for later screens and runs.
+func (m *DetailModel) pickVersion(v data.GameVersion) tea.Cmd {
+	m.setVersion(v)
+	return func() tea.Msg { return setVersionMsg{version: v} }
+}
+
+// filterMoves handles the Moves tab's filter keys, reporting whether msg
+// was one: they hide or show the level-up, TM/HM, tutor and egg sections
+// and cycle the type and category filters.
+func (m *DetailModel) filterMoves(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, keys.HideLevelUp):
+		m.hiddenMethods[data.LearnLevelUp] = !m.hiddenMethods[data.LearnLevelUp]
+	case key.Matches(msg, keys.HideMachines):
+		m.hiddenMethods[data.LearnMachine] = !m.hiddenMethods[data.LearnMachine]
+	case key.Matches(msg, keys.HideTutor):
+		m.hiddenMethods[data.LearnTutor] = !m.hiddenMethods[data.LearnTutor]
+	case key.Matches(msg, keys.HideEgg):
+		m.hiddenMethods[data.LearnEgg] = !m.hiddenMethods[data.LearnEgg]
+	case key.Matches(msg, keys.TypeFilter):
+		m.moveType = nextType(m.moveType, data.GenForVersion(m.selectedVersion))
+	case key.Matches(msg, keys.CategoryFilter):
+		m.moveCategory = (m.moveCategory + 1) % 4
+	default:
+		return false
+	}
+	m.clampCursors()
+	return true
+}
+
+// versionMoves returns the moves learned in the selected version that pass
+// the Moves tab's filters, leaving out any missing from the move table. They
+// come grouped by LearnMethod, in the order the tab shows them: level-up
+// moves by level, TMs by machine number, tutor and egg moves by name.
+func (m DetailModel) versionMoves() []data.LearnedMove {
+	if m.pokemon == nil {
+		return nil
+	}
+	gen := data.GenForVersion(m.selectedVersion)
+	var moves []data.LearnedMove
+	for _, vls := range m.pokemon.Moves {
+		if vls.Version != m.selectedVersion {
+			continue
+		}
+		for _, lm := range vls.Moves {
+			mv := data.MoveByID(lm.MoveID)
+			switch {
+			case mv == nil || m.hiddenMethods[lm.Method]:
+			case m.moveType != data.TypeNone && mv.TypeForGen(gen) != m.moveType:
+			case m.moveCategory != 0 && mv.CategoryForGen(gen) != data.MoveCategory(m.moveCategory-1):
+			default:
+				moves = append(moves, lm)
+			}
+		}
+		break
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		a, b := moves[i], moves[j]
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		switch a.Method {
+		case data.LearnLevelUp:
+			return a.LevelLearnedAt < b.LevelLearnedAt
+		case data.LearnMachine:
+			return a.MachineNumber < b.MachineNumber
+		}
+		return moveName(a.Move(), m.lang) < moveName(b.Move(), m.lang)
+	})
+	return moves
+}
+
+// isSTAB reports whether mv gets the same-type attack bonus when used by
+// the Pokémon in gen: it's a damaging move of one of the Pokémon's types,
+// with a fixed power the bonus can multiply.
+func (m DetailModel) isSTAB(mv *data.Move, gen data.Generation) bool {
+	if power, _, _ := mv.ValuesForGen(gen); power == 0 || mv.CategoryForGen(gen) == data.CategoryStatus {
+		return false
+	}
+	t := mv.TypeForGen(gen)
+	types := m.pokemon.TypesForGen(gen)
+	return t != data.TypeNone && (t == types[0] || t == types[1])
+}
+
+// versionLocations returns the encounters in the selected version.
+func (m DetailModel) versionLocations() []data.Location {
+	if m.pokemon == nil {
+		return nil
+	}
+	var locs []data.Location
+	for _, loc := range m.pokemon.Locations {
+		if loc.Game == m.selectedVersion {
+			locs = append(locs, loc)
+		}
+	}
+	return locs
+}
+
+// clampCursors keeps both cursors on a row after the lists change.
+func (m *DetailModel) clampCursors() {
+	m.moveCursor = max(min(m.moveCursor, len(m.versionMoves())-1), 0)
+	m.locationCursor = max(min(m.locationCursor, len(m.versionLocations())-1), 0)
+}
+
+// tabRows is how many rows of the Moves or Locations tab fit on screen.
+func (m DetailModel) tabRows() int {
+	return max(m.height-10, maxVisible)
+}
+
+// movesWindow returns the range of moves the Moves tab shows, keeping the
+// cursor in view. Each learn method's section header takes one of the tab's
+// rows, and the first move shown always gets its section's header.
+func (m DetailModel) movesWindow(moves []data.LearnedMove) (start, end int) {
+	lines := func(from, to int) int {
+		n := 0
+		for i := from; i < to; i++ {
+			if i == from || moves[i-1].Method != moves[i].Method {
+				n++
+			}
+			n++
+		}
+		return n
+	}
+	rows := m.tabRows()
+	for start < m.moveCursor && lines(start, m.moveCursor+1) > rows {
+		start++
+	}
+	end = start
+	for end < len(moves) && lines(start, end+1) <= rows {
+		end++
+	}
+	return start, end
+}
+
+// step shows the loaded Pokémon with the nearest dex number before (dir -1)
+// or after (dir 1) this one, keeping the tab, cursors and version. A Pokémon
+// from a save is dropped, as it's another species; at either end nothing
+// changes.
+func (m *DetailModel) step(dir int) {
+	if m.pokemon == nil {
+		return
+	}
+	var next *data.Pokemon
+	nearest := 0
+	for _, p := range data.ByID {
+		// d is how far p is in the direction of travel.
+		if d := (int(p.ID) - int(m.pokemon.ID)) * dir; d > 0 && (next == nil || d < nearest) {
+			next, nearest = p, d
+		}
+	}
+	if next != nil {
+		m.pokemon = next
+		m.individual = nil
+		m.clampCursors()
+	}
+}
+
 func (m DetailModel) View() string {
 	if m.pokemon == nil {
 		return "Pokemon not found."
@@ -114,8 +340,12 @@ func (m DetailModel) View() string {
 	var sb strings.Builder
 
 	// Header
-	name := capitalize(m.pokemon.Name)
-	sb.WriteString(fmt.Sprintf("  #%03d %-14s ver: %s\n", m.pokemon.ID, name, m.selectedVersion))
+	name := pokemonName(m.pokemon, m.lang)
+	sb.WriteString(fmt.Sprintf("  #%03d %s ver: %s", m.pokemon.ID, padRight(name, 14), m.selectedVersion))
+	if m.tracker != nil {
+		sb.WriteString("  " + m.tracker.Status(m.pokemon.ID).String())
+	}
+	sb.WriteString("\n")
 
 	// Type badges
 	t1 := types[0]
@@ -143,29 +373,52 @@ func (m DetailModel) View() string {
 	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)) + "\n")
 
 	// Tab content
-	switch m.activeTab {
-	case tabStats:
+	switch {
+	case m.picker.open:
+		sb.WriteString(m.picker.view(m.pokemon, m.selectedVersion) + "\n")
+	case m.activeTab == tabStats:
 		sb.WriteString(m.renderStatsTab(gen, types))
-	case tabMoves:
+	case m.activeTab == tabMoves:
 		sb.WriteString(m.renderMovesTab(gen))
-	case tabLocations:
+	case m.activeTab == tabLocations:
 		sb.WriteString(m.renderLocationsTab())
 	}
 
 	// Footer
 	sb.WriteString("\n")
-	sb.WriteString(footerStyle.Render("  esc:back  tab:switch  1-9:version  ↑↓:scroll"))
+	help := func(b key.Binding) string { return keyHelp(b, false) }
+	footer := fmt.Sprintf("  %s:back  %s:switch  %s%s:prev/next  %s/%s:version  %s%s:select  %s:open",
+		help(keys.Escape), help(keys.Tab), help(keys.Left), help(keys.Right), help(keys.Version),
+		numberKeysHelp(keys.VersionKeys[:]), help(keys.Up), help(keys.Down), help(keys.Enter))
+	if m.activeTab == tabMoves {
+		footer += fmt.Sprintf("  %s:sections  %s:type  %s:category",
+			keysHelp(false, keys.HideLevelUp, keys.HideMachines, keys.HideTutor, keys.HideEgg),
+			help(keys.TypeFilter), help(keys.CategoryFilter))
+	}
+	if m.tracker != nil {
+		footer += fmt.Sprintf("  %s:seen/caught", help(keys.Track))
+	}
+	sb.WriteString(footerStyle.Render(footer))
+	if m.err != nil {
+		sb.WriteString("\n  " + m.err.Error())
+	}
 	return sb.String()
 }
 
+// statRow is one labelled base stat in the Stats tab.
+type statRow struct {
+	label string
+	val   uint8
+}
+
 func (m DetailModel) renderStatsTab(gen data.Generation, types [2]data.PokeType) string {
 	var sb strings.Builder
 	p := m.pokemon
 
 	// Abilities (Gen 3 only)
 	if gen >= 3 {
-		ab1 := abilityName(p.Abilities[0])
-		ab2 := abilityName(p.Abilities[1])
+		ab1 := abilityName(p.Abilities[0], m.lang)
+		ab2 := abilityName(p.Abilities[1], m.lang)
 		if ab2 != "" {
 			sb.WriteString(fmt.Sprintf("  Ability:  %s / %s\n", ab1, ab2))
 		} else if ab1 != "" {
@@ -179,10 +432,7 @@ func (m DetailModel) renderStatsTab(gen data.Generation, types [2]data.PokeType)
 	sb.WriteString("\n")
 
 	// Base stats
-	stats := []struct {
-		label string
-		val   uint8
-	}{
+	stats := []statRow{
 		{"HP   ", p.Stats.HP},
 		{"Atk  ", p.Stats.Attack},
 		{"Def  ", p.Stats.Defense},
@@ -190,6 +440,10 @@ func (m DetailModel) renderStatsTab(gen data.Generation, types [2]data.PokeType)
 		{"SpDef", p.Stats.SpecialDefense},
 		{"Speed", p.Stats.Speed},
 	}
+	if m.individual != nil {
+		sb.WriteString(m.renderIndividual(stats))
+		return sb.String()
+	}
 	for _, s := range stats {
 		note := ""
 		if gen < 2 && (s.label == "SpAtk" || s.label == "SpDef") {
@@ -201,25 +455,86 @@ func (m DetailModel) renderStatsTab(gen data.Generation, types [2]data.PokeType)
 	return sb.String()
 }
 
+// renderIndividual lists a save Pokémon's actual stats, IVs and EVs next to
+// the species' base stats.
+func (m DetailModel) renderIndividual(base []statRow) string {
+	var sb strings.Builder
+	mon := m.individual
+	header := fmt.Sprintf("  %s Lv%d", mon.Nickname, mon.Level)
+	if mon.Gen >= 3 {
+		header += fmt.Sprintf("  %s nature", mon.Nature())
+		if ab := abilityName(mon.Ability(), m.lang); ab != "" {
+			header += "  " + ab
+		}
+	}
+	if mon.Shiny() && mon.Gen >= 2 {
+		header += "  ★ Shiny"
+	}
+	sb.WriteString(header + "\n")
+	sb.WriteString(fmt.Sprintf("  OT: %s (%05d)\n", mon.OTName, mon.OTID))
+	var moves []string
+	for _, id := range mon.Moves {
+		if id == 0 {
+			continue
+		}
+		if mv := data.MoveByID(id); mv != nil {
+			moves = append(moves, moveName(mv, m.lang))
+		} else {
+			moves = append(moves, fmt.Sprintf("move %d", id))
+		}
+	}
+	if len(moves) > 0 {
+		sb.WriteString("  Moves: " + strings.Join(moves, ", ") + "\n")
+	}
+	sb.WriteString("\n")
+	ivLabel, evLabel := "IV", "EV"
+	if mon.Gen < 3 {
+		ivLabel, evLabel = "DV", "StatExp"
+	}
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("  %-5s  %-10s  %4s  %6s  %3s  %7s\n",
+		"", "Base", "", "Actual", ivLabel, evLabel)))
+
+	actual, _ := mon.ActualStats()
+	rows := []struct{ actual, iv, ev uint16 }{
+		{actual.HP, mon.IVs.HP, mon.EVs.HP},
+		{actual.Attack, mon.IVs.Attack, mon.EVs.Attack},
+		{actual.Defense, mon.IVs.Defense, mon.EVs.Defense},
+		{actual.SpecialAttack, mon.IVs.SpecialAttack, mon.EVs.SpecialAttack},
+		{actual.SpecialDefense, mon.IVs.SpecialDefense, mon.EVs.SpecialDefense},
+		{actual.Speed, mon.IVs.Speed, mon.EVs.Speed},
+	}
+	for i, s := range base {
+		r := rows[i]
+		sb.WriteString(fmt.Sprintf("  %s  %s  %4d  %6d  %3d  %7d\n",
+			s.label, StatBar(s.val), s.val, r.actual, r.iv, r.ev))
+	}
+	return sb.String()
+}
+
 func (m DetailModel) renderMovesTab(gen data.Generation) string {
 	var sb strings.Builder
 	p := m.pokemon
 
-	// Collect moves for selectedVersion
-	var moves []data.LearnedMove
 	noData := true
 	for _, vls := range p.Moves {
 		if vls.Version == m.selectedVersion {
-			moves = vls.Moves
 			noData = false
 			break
 		}
 	}
+	moves := m.versionMoves()
+	filters := m.moveFilters()
+	if filters != "" && !noData {
+		sb.WriteString(dimStyle.Render("  "+filters) + "\n")
+	}
 
 	if noData || len(moves) == 0 {
-		if noData {
+		switch {
+		case noData:
 			sb.WriteString(dimStyle.Render("  No data for this version"))
-		} else {
+		case filters != "":
+			sb.WriteString(dimStyle.Render("  No moves match the filters"))
+		default:
 			sb.WriteString(dimStyle.Render("  No moves for this version"))
 		}
 		sb.WriteString("\n")
@@ -227,30 +542,32 @@ func (m DetailModel) renderMovesTab(gen data.Generation) string {
 	}
 
 	// Header row
-	sb.WriteString(headerStyle.Render(fmt.Sprintf("  %-14s %-8s %-5s %3s %3s %3s  %-6s\n",
-		"Name", "Type", "Cat", "Pwr", "Acc", "PP", "Lv/TM")))
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("  %-14s %-8s %-5s %3s %3s %3s  %-6s %s",
+		"Name", "Type", "Cat", "Pwr", "Acc", "PP", "Lv/TM", "STAB")) + "\n")
 
-	// Apply scroll
-	start := m.moveScroll
-	if start > len(moves) {
-		start = len(moves)
+	var counts [4]int
+	for _, lm := range moves {
+		counts[lm.Method]++
 	}
 
-	for _, lm := range moves[start:] {
-		if data.AllMoves == nil || int(lm.MoveID) >= len(data.AllMoves) || data.AllMoves[lm.MoveID] == nil {
-			continue
+	start, end := m.movesWindow(moves)
+	for i := start; i < end; i++ {
+		lm := moves[i]
+		if i == start || moves[i-1].Method != lm.Method {
+			sb.WriteString(headerStyle.Render(fmt.Sprintf("  %s (%d)", lm.Method, counts[lm.Method])) + "\n")
 		}
 		mv := lm.Move()
 		moveType := mv.TypeForGen(gen)
 		cat := mv.CategoryForGen(gen)
 
+		pwr, accuracy, pp := mv.ValuesForGen(gen)
 		power := "—"
-		if mv.Power > 0 {
-			power = fmt.Sprintf("%3d", mv.Power)
+		if pwr > 0 {
+			power = fmt.Sprintf("%3d", pwr)
 		}
 		acc := "—"
-		if mv.Accuracy > 0 {
-			acc = fmt.Sprintf("%3d", mv.Accuracy)
+		if accuracy > 0 {
+			acc = fmt.Sprintf("%3d", accuracy)
 		}
 
 		lvTM := ""
@@ -273,24 +590,43 @@ func (m DetailModel) renderMovesTab(gen data.Generation) string {
 			lvTM = "Egg"
 		}
 
-		sb.WriteString(fmt.Sprintf("  %-14s %-8s %-5s %3s %3s %3d  %-6s\n",
-			mv.Name, moveType.String(), cat.String(),
-			power, acc, mv.PP, lvTM))
+		stab := ""
+		if m.isSTAB(mv, gen) {
+			stab = "STAB"
+		}
+
+		row := fmt.Sprintf("%s %-8s %-5s %3s %3s %3d  %-6s %s",
+			padRight(moveName(mv, m.lang), 14), moveType.String(), cat.String(),
+			power, acc, pp, lvTM, stab)
+		sb.WriteString(cursorRow(row, i == m.moveCursor) + "\n")
 	}
 	return sb.String()
 }
 
-func (m DetailModel) renderLocationsTab() string {
-	var sb strings.Builder
-	p := m.pokemon
-
-	// Filter locations by selected version
-	var locs []data.Location
-	for _, loc := range p.Locations {
-		if loc.Game == m.selectedVersion {
-			locs = append(locs, loc)
+// moveFilters describes the Moves tab's active filters, or is "" when
+// every move is shown.
+func (m DetailModel) moveFilters() string {
+	var parts, hidden []string
+	for method, ok := range m.hiddenMethods {
+		if ok {
+			hidden = append(hidden, data.LearnMethod(method).String())
 		}
 	}
+	if len(hidden) > 0 {
+		parts = append(parts, "Hidden: "+strings.Join(hidden, ", "))
+	}
+	if m.moveType != data.TypeNone {
+		parts = append(parts, "Type: "+m.moveType.String())
+	}
+	if m.moveCategory != 0 {
+		parts = append(parts, "Category: "+data.MoveCategory(m.moveCategory-1).String())
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m DetailModel) renderLocationsTab() string {
+	var sb strings.Builder
+	locs := m.versionLocations()
 
 	if len(locs) == 0 {
 		sb.WriteString(dimStyle.Render("  Not found in the wild for this version"))
@@ -298,23 +634,36 @@ func (m DetailModel) renderLocationsTab() string {
 		return sb.String()
 	}
 
-	start := m.locationScroll
-	if start > len(locs) {
-		start = len(locs)
+	rows := m.tabRows()
+	start := 0
+	if m.locationCursor >= rows {
+		start = m.locationCursor - rows + 1
 	}
+	end := min(start+rows, len(locs))
 
 	sb.WriteString(headerStyle.Render(fmt.Sprintf("  %-30s %-12s %-8s %s\n",
 		"Area", "Method", "Levels", "Chance")))
-	for _, loc := range locs[start:] {
+	for i := start; i < end; i++ {
+		loc := locs[i]
 		levels := fmt.Sprintf("%d-%d", loc.MinLevel, loc.MaxLevel)
-		sb.WriteString(fmt.Sprintf("  %-30s %-12s %-8s %d%%\n",
-			loc.AreaName, loc.EncounterMethod.String(), levels, loc.Chance))
+		row := fmt.Sprintf("%-30s %-12s %-8s %d%%",
+			loc.AreaName, loc.EncounterMethod.String(), levels, loc.Chance)
+		sb.WriteString(cursorRow(row, i == m.locationCursor) + "\n")
 	}
 	return sb.String()
 }
 
+// cursorRow indents a tab row, marking and highlighting it when it's the
+// one under the cursor.
+func cursorRow(row string, selected bool) string {
+	if selected {
+		return selectedRowStyle.Render("> " + row)
+	}
+	return "  " + row
+}
+
 // abilityName returns the display name for an ability ID, or "" if not found.
-func abilityName(id data.AbilityID) string {
+func abilityName(id data.AbilityID, lang data.Language) string {
 	if id == 0 || data.AllAbilities == nil || int(id) >= len(data.AllAbilities) {
 		return ""
 	}
@@ -322,6 +671,9 @@ func abilityName(id data.AbilityID) string {
 	if ab == nil {
 		return ""
 	}
+	if n := localName(ab.Names, lang); n != "" {
+		return n
+	}
 	return capitalize(strings.ReplaceAll(ab.Name, "-", " "))
 }
 
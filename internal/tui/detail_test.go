@@ -1,11 +1,15 @@
 package tui
 
 import (
+	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+	"github.com/davidlawson7/pokedex/internal/tracker"
 )
 
 // buildDetailModel creates a DetailModel with a hand-crafted Pokemon for testing.
@@ -55,39 +59,53 @@ var detailTestCharizardWithBite = &data.Pokemon{
 	},
 }
 
-func setupMovesForTest() {
-	if data.AllMoves == nil {
-		data.AllMoves = make([]*data.Move, 50)
-	}
-	if len(data.AllMoves) <= 44 {
-		newMoves := make([]*data.Move, 50)
-		copy(newMoves, data.AllMoves)
-		data.AllMoves = newMoves
-	}
-	data.AllMoves[44] = &data.Move{
-		ID:        44,
-		Name:      "Bite",
-		Type:      data.TypeDark,
-		Category:  data.CategoryPhysical,
-		Power:     60,
-		Accuracy:  100,
-		PP:        25,
-		PastTypes: []data.MoveTypePast{{UntilGen: 1, Type: data.TypeNormal}},
+// setupMovesForTest makes moves the whole move table until the test ends.
+func setupMovesForTest(t *testing.T, moves ...*data.Move) {
+	t.Helper()
+	saved := data.AllMoves
+	t.Cleanup(func() { data.AllMoves = saved })
+	data.AllMoves = nil
+	for _, mv := range moves {
+		data.AllMoves = putAt(data.AllMoves, int(mv.ID), mv)
 	}
 }
 
-func setupAbilitiesForTest() {
-	if data.AllAbilities == nil {
-		data.AllAbilities = make([]*data.Ability, 100)
+// setupAbilitiesForTest makes abilities the whole ability table until the
+// test ends.
+func setupAbilitiesForTest(t *testing.T, abilities ...*data.Ability) {
+	t.Helper()
+	saved := data.AllAbilities
+	t.Cleanup(func() { data.AllAbilities = saved })
+	data.AllAbilities = nil
+	for _, a := range abilities {
+		data.AllAbilities = putAt(data.AllAbilities, int(a.ID), a)
 	}
-	if len(data.AllAbilities) <= 65 {
-		newAbs := make([]*data.Ability, 100)
-		copy(newAbs, data.AllAbilities)
-		data.AllAbilities = newAbs
+}
+
+// putAt stores v at index i of table, growing the table to fit.
+func putAt[T any](table []*T, i int, v *T) []*T {
+	if i >= len(table) {
+		table = append(table, make([]*T, i+1-len(table))...)
 	}
-	data.AllAbilities[65] = &data.Ability{ID: 65, Name: "overgrow", ShortDesc: "Powers up Grass-type moves when the Pokémon's HP is low."}
-	data.AllAbilities[42] = &data.Ability{ID: 42, Name: "magnet-pull", ShortDesc: "Prevents Steel-type Pokémon from fleeing."}
-	data.AllAbilities[5] = &data.Ability{ID: 5, Name: "sturdy", ShortDesc: "The Pokémon is unaffected by one-hit KO moves."}
+	table[i] = v
+	return table
+}
+
+var detailTestBite = &data.Move{
+	ID:        44,
+	Name:      "Bite",
+	Type:      data.TypeDark,
+	Category:  data.CategoryPhysical,
+	Power:     60,
+	Accuracy:  100,
+	PP:        25,
+	PastTypes: []data.MoveTypePast{{UntilGen: 1, Type: data.TypeNormal}},
+}
+
+var detailTestAbilities = []*data.Ability{
+	{ID: 65, Name: "overgrow", ShortDesc: "Powers up Grass-type moves when the Pokémon's HP is low."},
+	{ID: 42, Name: "magnet-pull", ShortDesc: "Prevents Steel-type Pokémon from fleeing."},
+	{ID: 5, Name: "sturdy", ShortDesc: "The Pokémon is unaffected by one-hit KO moves."},
 }
 
 func TestDetailModel_TabCycles(t *testing.T) {
@@ -132,15 +150,15 @@ func TestDetailModel_ShiftTabCyclesBack(t *testing.T) {
 	}
 }
 
-func TestDetailModel_EscapeEmitsSwitchMsg(t *testing.T) {
+func TestDetailModel_EscapeEmitsBackMsg(t *testing.T) {
 	m := buildDetailModel(detailTestBulbasaur)
 	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
 	if cmd == nil {
 		t.Fatal("expected cmd on esc, got nil")
 	}
 	msg := cmd()
-	if _, ok := msg.(switchToSearchMsg); !ok {
-		t.Errorf("expected switchToSearchMsg, got %T", msg)
+	if _, ok := msg.(backMsg); !ok {
+		t.Errorf("expected backMsg, got %T", msg)
 	}
 }
 
@@ -154,6 +172,13 @@ func TestDetailModel_VersionKey1SetsRed(t *testing.T) {
 	}
 }
 
+func TestDetailModel_VersionKeyIsNotSaved(t *testing.T) {
+	m := buildDetailModel(detailTestBulbasaur)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}}); cmd != nil {
+		t.Errorf("3 returned %#v, want the version kept to this screen", cmd())
+	}
+}
+
 func TestDetailModel_VersionKey5SetsSilver(t *testing.T) {
 	m := buildDetailModel(detailTestBulbasaur)
 	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
@@ -198,7 +223,7 @@ func TestDetailModel_TypesVersionAware_Magnemite(t *testing.T) {
 }
 
 func TestDetailModel_MoveCategoryVersionAware(t *testing.T) {
-	setupMovesForTest()
+	setupMovesForTest(t, detailTestBite)
 	m := buildDetailModel(detailTestCharizardWithBite)
 
 	// Gen 1 (Red): Bite is Normal/Physical (type-based split, Normal = Physical)
@@ -226,7 +251,7 @@ func TestDetailModel_MoveCategoryVersionAware(t *testing.T) {
 }
 
 func TestDetailModel_AbilitiesHiddenInGen1(t *testing.T) {
-	setupAbilitiesForTest()
+	setupAbilitiesForTest(t, detailTestAbilities...)
 	m := buildDetailModel(detailTestMagnemite)
 	m.selectedVersion = data.GameRed
 	view := m.View()
@@ -239,7 +264,7 @@ func TestDetailModel_AbilitiesHiddenInGen1(t *testing.T) {
 }
 
 func TestDetailModel_AbilitiesShownInGen3(t *testing.T) {
-	setupAbilitiesForTest()
+	setupAbilitiesForTest(t, detailTestAbilities...)
 	m := buildDetailModel(detailTestMagnemite)
 	m.selectedVersion = data.GameEmerald
 	view := m.View()
@@ -248,29 +273,323 @@ func TestDetailModel_AbilitiesShownInGen3(t *testing.T) {
 	}
 }
 
-func TestDetailModel_ScrollMoves(t *testing.T) {
-	setupMovesForTest()
+func TestDetailModel_MoveCursor(t *testing.T) {
+	setupMovesForTest(t, detailTestBite)
+	p := *detailTestCharizardWithBite
+	p.Moves = []data.VersionedLearnset{{
+		Version: data.GameRed,
+		Moves: []data.LearnedMove{
+			{MoveID: 44, Method: data.LearnLevelUp, LevelLearnedAt: 33},
+			{MoveID: 49, Method: data.LearnMachine}, // not in the move table
+			{MoveID: 44, Method: data.LearnMachine, MachineNumber: 12},
+		},
+	}}
+	m := buildDetailModel(&p)
+	t.Cleanup(func() { data.ByID[p.ID] = detailTestCharizardWithBite })
+	m.activeTab = tabMoves
+
+	keys := []struct {
+		key  tea.KeyType
+		want int
+	}{
+		{tea.KeyDown, 1},
+		{tea.KeyDown, 1}, // the missing move isn't a row
+		{tea.KeyUp, 0},
+		{tea.KeyUp, 0},
+		{tea.KeyDown, 1},
+	}
+	for i, k := range keys {
+		next, _ := m.Update(tea.KeyMsg{Type: k.key})
+		if m = next.(DetailModel); m.moveCursor != k.want {
+			t.Fatalf("key %d: moveCursor = %d, want %d", i, m.moveCursor, k.want)
+		}
+	}
+	if view := m.View(); !strings.Contains(view, "> Bite") || !strings.Contains(view, "TM12") {
+		t.Errorf("expected the TM12 row under the cursor:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(switchToMoveMsg); !ok || msg.moveID != 44 {
+		t.Errorf("enter = %#v, want switchToMoveMsg for Bite", msg)
+	}
+}
+
+func TestDetailModel_MovesGroupedAndFiltered(t *testing.T) {
+	setupMovesForTest(t, detailTestBite,
+		&data.Move{ID: 45, Name: "Growl", Type: data.TypeNormal, Category: data.CategoryStatus},
+		&data.Move{ID: 52, Name: "Ember", Type: data.TypeFire, Category: data.CategorySpecial, Power: 40},
+		&data.Move{ID: 53, Name: "Flamethrower", Type: data.TypeFire, Category: data.CategorySpecial, Power: 90})
+
+	p := *detailTestCharizardWithBite
+	p.Moves = []data.VersionedLearnset{{
+		Version: data.GameRed,
+		Moves: []data.LearnedMove{
+			{MoveID: 45, Method: data.LearnEgg},
+			{MoveID: 53, Method: data.LearnMachine, MachineNumber: 30},
+			{MoveID: 52, Method: data.LearnLevelUp, LevelLearnedAt: 9},
+			{MoveID: 44, Method: data.LearnMachine, MachineNumber: 10},
+			{MoveID: 45, Method: data.LearnLevelUp, LevelLearnedAt: 1},
+		},
+	}}
+	m := buildDetailModel(&p)
+	t.Cleanup(func() { data.ByID[p.ID] = detailTestCharizardWithBite })
+	m.activeTab = tabMoves
+
+	view := m.View()
+	last := -1
+	for _, want := range []string{"Level (2)", "Growl", "Ember", "TM/HM (2)", "Bite", "Flamethrower", "Egg (1)"} {
+		i := strings.Index(view[last+1:], want)
+		if i < 0 {
+			t.Fatalf("expected %q after position %d:\n%s", want, last, view)
+		}
+		last += 1 + i
+	}
+	for _, line := range strings.Split(view, "\n") {
+		switch {
+		case strings.Contains(line, "Ember") || strings.Contains(line, "Flamethrower"):
+			if !strings.Contains(line, "STAB") {
+				t.Errorf("Fire move should be marked STAB: %q", line)
+			}
+		case strings.Contains(line, "Bite") || strings.Contains(line, "Growl"):
+			if strings.Contains(line, "STAB") {
+				t.Errorf("non-STAB move marked: %q", line)
+			}
+		}
+	}
+
+	press := func(r rune) {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = next.(DetailModel)
+	}
+	press('m')
+	if view := m.View(); strings.Contains(view, "Flamethrower") || !strings.Contains(view, "Hidden: TM/HM") {
+		t.Errorf("TM/HM section should be hidden:\n%s", view)
+	}
+	press('m')
+	press('t')
+	press('t') // Normal, then Fire
+	if got := len(m.versionMoves()); got != 2 {
+		t.Errorf("%d Fire moves, want 2", got)
+	}
+	press('c')
+	press('c') // Physical, then Special
+	press('t') // Water
+	if view := m.View(); !strings.Contains(view, "No moves match the filters") {
+		t.Errorf("expected an empty filtered list:\n%s", view)
+	}
+}
+
+func TestDetailModel_MovesWindowCountsSectionHeaders(t *testing.T) {
+	var moves []*data.Move
+	var learned []data.LearnedMove
+	for i := range 20 {
+		id := data.MoveID(i + 1)
+		moves = append(moves, &data.Move{ID: id, Name: fmt.Sprintf("Move %02d", id), Type: data.TypeNormal})
+		learned = append(learned, data.LearnedMove{MoveID: id, Method: data.LearnMethod(i / 5), LevelLearnedAt: uint8(i)})
+	}
+	setupMovesForTest(t, moves...)
+	p := *detailTestCharizardWithBite
+	p.Moves = []data.VersionedLearnset{{Version: data.GameRed, Moves: learned}}
+	m := buildDetailModel(&p)
+	t.Cleanup(func() { data.ByID[p.ID] = detailTestCharizardWithBite })
+	m.activeTab = tabMoves
+
+	for cursor := range learned {
+		m.moveCursor = cursor
+		start, end := m.movesWindow(m.versionMoves())
+		if cursor < start || cursor >= end {
+			t.Fatalf("cursor %d outside the window [%d, %d)", cursor, start, end)
+		}
+		view := m.renderMovesTab(1)
+		if !strings.Contains(view, fmt.Sprintf("> Move %02d", cursor+1)) {
+			t.Fatalf("cursor %d not shown:\n%s", cursor, view)
+		}
+		// The column header row comes before the tab's rows.
+		if lines := strings.Count(view, "\n") - 1; lines > m.tabRows() {
+			t.Fatalf("cursor %d: %d rows, want at most %d:\n%s", cursor, lines, m.tabRows(), view)
+		}
+	}
+}
+
+func TestDetailModel_STABNeedsPower(t *testing.T) {
+	m := DetailModel{pokemon: &data.Pokemon{Types: [2]data.PokeType{data.TypeFighting, data.TypeNone}}}
+	seismicToss := &data.Move{ID: 69, Name: "seismic-toss", Type: data.TypeFighting, Category: data.CategoryPhysical}
+	if m.isSTAB(seismicToss, 1) {
+		t.Error("a move with no fixed power is marked STAB")
+	}
+	seismicToss.Power = 1
+	if !m.isSTAB(seismicToss, 1) {
+		t.Error("a Fighting move with power should be STAB for a Fighting type")
+	}
+}
+
+func TestDetailModel_TypeFilterSkipsTypesNotInGen(t *testing.T) {
 	m := buildDetailModel(detailTestCharizardWithBite)
 	m.activeTab = tabMoves
+	seen := map[data.PokeType]bool{}
+	for range 20 {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+		m = next.(DetailModel)
+		seen[m.moveType] = true
+	}
+	if seen[data.TypeDark] || seen[data.TypeSteel] {
+		t.Errorf("the Red type filter offered Dark or Steel: %v", seen)
+	}
+	if !seen[data.TypeDragon] || !seen[data.TypeNone] {
+		t.Errorf("the Red type filter should reach Dragon and wrap to every type: %v", seen)
+	}
 
-	// scroll down
-	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
-	dm2 := m2.(DetailModel)
-	if dm2.moveScroll != 1 {
-		t.Errorf("moveScroll = %d after down, want 1", dm2.moveScroll)
+	m.selectedVersion = data.GameGold
+	m.moveType = data.TypeSteel
+	m.setVersion(data.GameRed)
+	if m.moveType != data.TypeNone {
+		t.Errorf("moveType = %s after switching to Red, want none", m.moveType)
+	}
+}
+
+func TestDetailModel_LocationCursorOpensArea(t *testing.T) {
+	p := *detailTestBulbasaur
+	p.Locations = []data.Location{
+		{Game: data.GameRed, AreaName: "viridian-forest"},
+		{Game: data.GameBlue, AreaName: "route-2"},
+		{Game: data.GameRed, AreaName: "route-24"},
+	}
+	m := buildDetailModel(&p)
+	t.Cleanup(func() { data.ByID[p.ID] = detailTestBulbasaur })
+	m.activeTab = tabLocations
+
+	for range 3 {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = next.(DetailModel)
 	}
+	if m.locationCursor != 1 {
+		t.Fatalf("locationCursor = %d, want 1 (Red has two areas)", m.locationCursor)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(switchToAreaMsg); !ok || msg.area != "route-24" || msg.version != data.GameRed {
+		t.Errorf("enter = %#v, want switchToAreaMsg for route-24 in Red", msg)
+	}
+
+	// Blue has one area, so switching versions pulls the cursor back.
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if m = next.(DetailModel); m.locationCursor != 0 {
+		t.Errorf("locationCursor = %d after switching to Blue, want 0", m.locationCursor)
+	}
+}
 
-	// scroll up
-	m3, _ := dm2.Update(tea.KeyMsg{Type: tea.KeyUp})
+func TestDetailModel_CtrlXCyclesTrackerStatus(t *testing.T) {
+	m := buildDetailModel(detailTestBulbasaur)
+	m.tracker = tracker.New(filepath.Join(t.TempDir(), tracker.FileName))
+
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	m3, _ := m2.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
 	dm3 := m3.(DetailModel)
-	if dm3.moveScroll != 0 {
-		t.Errorf("moveScroll = %d after up, want 0", dm3.moveScroll)
+	if got := dm3.tracker.Status(1); got != tracker.Caught {
+		t.Errorf("status after two ctrl+x = %v, want Caught", got)
+	}
+	if !strings.Contains(dm3.View(), "Caught") {
+		t.Error("expected Caught status in detail header")
 	}
+}
 
-	// scroll up from 0 clamps
-	m4, _ := dm3.Update(tea.KeyMsg{Type: tea.KeyUp})
-	dm4 := m4.(DetailModel)
-	if dm4.moveScroll != 0 {
-		t.Errorf("moveScroll = %d after up from 0, want 0 (clamped)", dm4.moveScroll)
+func TestDetailModel_IndividualStatsShown(t *testing.T) {
+	m := buildDetailModel(detailTestBulbasaur)
+	m.individual = &save.Pokemon{Species: 1, Nickname: "Bulby", Level: 5, Gen: 3, OTName: "MAY", OTID: 2468, PID: 6,
+		IVs: save.Stats{HP: 31, Attack: 31, Defense: 31, SpecialAttack: 31, SpecialDefense: 31, Speed: 31}}
+	view := m.View()
+	// HP = (2*45+31)*5/100 + 5 + 10 = 21; PID 6 is Docile (neutral)
+	// and, with TID 2468, not shiny.
+	for _, want := range []string{"Bulby Lv5", "Docile nature", "OT: MAY (02468)", "Actual", "    21", "IV"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in stats tab:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Shiny") {
+		t.Error("did not expect a shiny marker")
+	}
+}
+
+func TestDetailModel_LocalizedHeader(t *testing.T) {
+	p := *detailTestMagnemite
+	p.Names = data.LocalNames{data.LangGerman: "Magnetilo"}
+	saved := data.ByID[p.ID]
+	m := buildDetailModel(&p)
+	m.lang = data.LangGerman
+	t.Cleanup(func() { data.ByID[p.ID] = saved })
+	if v := m.View(); !strings.Contains(v, "#081 Magnetilo") {
+		t.Errorf("header doesn't show the German name:\n%s", v)
+	}
+}
+
+func TestDetailModel_LeftRightStepThroughDex(t *testing.T) {
+	byID := data.ByID
+	t.Cleanup(func() { data.ByID = byID })
+	data.ByID = map[uint16]*data.Pokemon{1: detailTestBulbasaur, 6: detailTestCharizardWithBite, 81: detailTestMagnemite}
+
+	m := NewDetailModel(6, 80, 24)
+	m.activeTab = tabMoves
+	m.moveCursor = 2
+	m.selectedVersion = data.GameGold
+	m.individual = &save.Pokemon{Species: 6}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = next.(DetailModel)
+	if m.pokemon.ID != 81 {
+		t.Fatalf("right from #006 = #%03d, want #081", m.pokemon.ID)
+	}
+	if m.activeTab != tabMoves || m.selectedVersion != data.GameGold {
+		t.Errorf("tab %d, version %v changed", m.activeTab, m.selectedVersion)
+	}
+	if m.moveCursor != 0 {
+		t.Errorf("moveCursor = %d, want 0 as #081 has no moves", m.moveCursor)
+	}
+	if m.individual != nil {
+		t.Error("the saved Pokémon should be dropped for another species")
+	}
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if m = next.(DetailModel); m.pokemon.ID != 81 {
+		t.Errorf("right from the last = #%03d, want to stay on #081", m.pokemon.ID)
+	}
+	for range 2 {
+		next, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		m = next.(DetailModel)
+	}
+	if m.pokemon.ID != 1 {
+		t.Errorf("left twice from #081 = #%03d, want #001", m.pokemon.ID)
+	}
+}
+
+func TestDetailModel_VersionPicker(t *testing.T) {
+	setupMovesForTest(t, detailTestBite)
+	m := buildDetailModel(detailTestCharizardWithBite)
+	m.selectedVersion = data.GameBlue
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = next.(DetailModel)
+	view := m.View()
+	for _, want := range []string{"Generation 1", "Generation 3", "LeafGreen", "Red        moves", "Gold       no data"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the picker:\n%s", want, view)
+		}
+	}
+
+	// Keys go to the picker while it's open, and esc only closes it.
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	next, cmd := next.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(DetailModel)
+	if cmd != nil || m.picker.open || m.selectedVersion != data.GameBlue {
+		t.Errorf("esc should close the picker without a change; version %v", m.selectedVersion)
+	}
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	next, _ = next.Update(tea.KeyMsg{Type: tea.KeyUp})
+	next, cmd = next.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(DetailModel)
+	if m.selectedVersion != data.GameRed {
+		t.Errorf("picked %v, want Red", m.selectedVersion)
+	}
+	if msg, ok := cmd().(setVersionMsg); !ok || msg.version != data.GameRed {
+		t.Errorf("enter = %#v, want setVersionMsg for Red", msg)
 	}
 }
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/search"
+)
+
+// facetKind is which group of the filter panel a checkbox belongs to.
+type facetKind byte
+
+const (
+	facetType    facetKind = 0
+	facetGen     facetKind = 1
+	facetVersion facetKind = 2
+	facetStage   facetKind = 3
+)
+
+var facetKindNames = [4]string{"Type", "Generation", "Catchable in", "Evolution stage"}
+
+func (k facetKind) String() string { return facetKindNames[k] }
+
+// facetItem is one checkbox: a type, generation, version or stage number.
+type facetItem struct {
+	kind  facetKind
+	value int
+}
+
+// label is the checkbox's text in the panel.
+func (it facetItem) label() string {
+	switch it.kind {
+	case facetType:
+		return data.PokeType(it.value).String()
+	case facetGen:
+		return fmt.Sprintf("Gen %d", it.value)
+	case facetVersion:
+		return data.GameVersion(it.value).String()
+	}
+	return fmt.Sprintf("Stage %d", it.value)
+}
+
+// chip is the item's text among the active filter chips.
+func (it facetItem) chip() string {
+	if it.kind == facetVersion {
+		return "in " + it.label()
+	}
+	return it.label()
+}
+
+// facetItems lists every checkbox in panel order.
+var facetItems = func() []facetItem {
+	var items []facetItem
+	for t := data.TypeNormal; t <= data.TypeSteel; t++ {
+		items = append(items, facetItem{facetType, int(t)})
+	}
+	for g := 1; g <= 3; g++ {
+		items = append(items, facetItem{facetGen, g})
+	}
+	for v := data.GameRed; v <= data.GameLeafGreen; v++ {
+		items = append(items, facetItem{facetVersion, int(v)})
+	}
+	for s := 1; s <= search.MaxStage; s++ {
+		items = append(items, facetItem{facetStage, s})
+	}
+	return items
+}()
+
+// panelHeight is how many checkbox rows the panel shows at once.
+const panelHeight = maxVisible
+
+// filterPanel is the search screen's side panel of checkbox filters. While
+// open it takes the keyboard; the facets stay applied after it closes.
+type filterPanel struct {
+	open   bool
+	cursor int
+	facets search.Facets
+}
+
+// flag returns the Facets field that holds it.
+func (p *filterPanel) flag(it facetItem) *bool {
+	switch it.kind {
+	case facetType:
+		return &p.facets.Types[it.value]
+	case facetGen:
+		return &p.facets.Gens[it.value]
+	case facetVersion:
+		return &p.facets.CatchableIn[it.value]
+	}
+	return &p.facets.Stages[it.value]
+}
+
+// filterPanelBindings lists the bindings the filter panel matches.
+func filterPanelBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Enter, &k.Escape, &k.Toggle, &k.Clear, &k.Filters}
+}
+
+// update handles a key while the panel is open, reporting whether the
+// facets changed.
+func (p *filterPanel) update(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, keys.Escape, keys.Filters):
+		p.open = false
+	case key.Matches(msg, keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if p.cursor < len(facetItems)-1 {
+			p.cursor++
+		}
+	case key.Matches(msg, keys.Toggle, keys.Enter):
+		f := p.flag(facetItems[p.cursor])
+		*f = !*f
+		return true
+	case key.Matches(msg, keys.Clear):
+		if !p.facets.Empty() {
+			p.facets = search.Facets{}
+			return true
+		}
+	}
+	return false
+}
+
+// View renders the panel as a bordered column of checkboxes, scrolled to
+// keep the cursor in sight, with each group under its heading.
+func (p filterPanel) View() string {
+	start := max(0, min(p.cursor-panelHeight/2, len(facetItems)-panelHeight))
+	end := min(start+panelHeight, len(facetItems))
+	var lines []string
+	for i := start; i < end; i++ {
+		it := facetItems[i]
+		if i == start || facetItems[i-1].kind != it.kind {
+			lines = append(lines, headerStyle.Render(it.kind.String()))
+		}
+		box := "[ ]"
+		if *p.flag(it) {
+			box = "[x]"
+		}
+		row := fmt.Sprintf("%s %s", box, it.label())
+		if i == p.cursor {
+			row = selectedRowStyle.Render(row)
+		}
+		lines = append(lines, row)
+	}
+	lines = append(lines, "", dimStyle.Render(keyHelp(keys.Toggle, false)+":toggle"),
+		dimStyle.Render(fmt.Sprintf("%s:clear %s:close", keyHelp(keys.Clear, false), keyHelp(keys.Escape, false))))
+	return borderStyle.Width(22).Render(strings.Join(lines, "\n"))
+}
+
+// chips renders the checked facets as a row of chips, or "" when none are.
+func (p filterPanel) chips() string {
+	var chips []string
+	for _, it := range facetItems {
+		if *p.flag(it) {
+			chips = append(chips, chipStyle.Render(it.chip()))
+		}
+	}
+	if len(chips) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(chips, " ")
+}
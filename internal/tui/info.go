@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// infoEntry is one Pokémon row of an info screen.
+type infoEntry struct {
+	id    uint16
+	label string
+}
+
+// InfoModel shows a move, ability or location area: a few lines about it and
+// the Pokémon that learn it, have it or are found there.
+type InfoModel struct {
+	title     string
+	lines     []string
+	listTitle string
+	entries   []infoEntry
+	cursor    int
+	width     int
+	height    int
+}
+
+// NewMoveInfoModel lists the move's stats, how its type, category, power,
+// accuracy and PP changed across generations, and every Pokémon that learns
+// it in any version, with the methods it's learned by.
+func NewMoveInfoModel(id data.MoveID, pokemon []*data.Pokemon, lang data.Language, width, height int) InfoModel {
+	m := InfoModel{title: "Unknown move", width: width, height: height}
+	mv := data.MoveByID(id)
+	if mv == nil {
+		return m
+	}
+	m.title = "Move: " + capitalize(strings.ReplaceAll(mv.Name, "-", " "))
+	if n := localName(mv.Names, lang); n != "" {
+		m.title = "Move: " + n
+	}
+	m.lines = []string{
+		fmt.Sprintf("Type: %s  Category: %s", TypeBadge(mv.Type.String()), mv.Category),
+		fmt.Sprintf("Power: %s  Accuracy: %s  PP: %d", orDash(mv.Power), orDash(mv.Accuracy), mv.PP),
+		"",
+		headerStyle.Render(fmt.Sprintf("%-6s %-8s %-5s %3s %3s %3s", "", "Type", "Cat", "Pwr", "Acc", "PP")),
+	}
+	for gen := mv.IntroducedIn(); gen <= 3; gen++ {
+		power, accuracy, pp := mv.ValuesForGen(gen)
+		m.lines = append(m.lines, fmt.Sprintf("Gen %-2d %-8s %-5s %3s %3s %3d",
+			gen, mv.TypeForGen(gen), mv.CategoryForGen(gen), orDash(power), orDash(accuracy), pp))
+	}
+	m.listTitle = "Learned by"
+	for _, p := range pokemon {
+		var methods [4]bool
+		for _, vls := range p.Moves {
+			for _, lm := range vls.Moves {
+				if lm.MoveID == id {
+					methods[lm.Method] = true
+				}
+			}
+		}
+		var how []string
+		for method, ok := range methods {
+			if ok {
+				how = append(how, data.LearnMethod(method).String())
+			}
+		}
+		if len(how) > 0 {
+			m.entries = append(m.entries, infoEntry{p.ID, fmt.Sprintf("%s  %s", pokemonLabel(p, lang), strings.Join(how, ", "))})
+		}
+	}
+	return m
+}
+
+// NewAbilityInfoModel shows the ability's description and the Pokémon with it,
+// with the slot each has it in.
+func NewAbilityInfoModel(id data.AbilityID, pokemon []*data.Pokemon, lang data.Language, width, height int) InfoModel {
+	m := InfoModel{title: "Unknown ability", width: width, height: height}
+	name := abilityName(id, lang)
+	if name == "" {
+		return m
+	}
+	m.title = "Ability: " + name
+	m.lines = []string{data.AllAbilities[id].ShortDesc}
+	m.listTitle = "Pokémon"
+	for _, p := range pokemon {
+		var slot string
+		switch id {
+		case p.Abilities[0]:
+			slot = "Slot 1"
+		case p.Abilities[1]:
+			slot = "Slot 2"
+		case p.HiddenAbility:
+			slot = "Hidden"
+		default:
+			continue
+		}
+		m.entries = append(m.entries, infoEntry{p.ID, fmt.Sprintf("%s  %s", pokemonLabel(p, lang), slot)})
+	}
+	return m
+}
+
+// NewAreaInfoModel lists the Pokémon found in a location area and the
+// versions they're found in. Given a version, it instead lists that
+// version's encounter table: each Pokémon's method, levels and chance.
+func NewAreaInfoModel(area string, version data.GameVersion, pokemon []*data.Pokemon, lang data.Language, width, height int) InfoModel {
+	m := InfoModel{
+		title:     "Location: " + capitalize(strings.ReplaceAll(area, "-", " ")),
+		listTitle: "Encounters",
+		width:     width,
+		height:    height,
+	}
+	if version != 0 {
+		m.lines = []string{"Version: " + version.String()}
+		for _, p := range pokemon {
+			for _, loc := range p.Locations {
+				if loc.AreaName == area && loc.Game == version {
+					m.entries = append(m.entries, infoEntry{p.ID, fmt.Sprintf("%s %-12s Lv%-6s %3d%%",
+						padRight(pokemonLabel(p, lang), 18), loc.EncounterMethod, fmt.Sprintf("%d-%d", loc.MinLevel, loc.MaxLevel), loc.Chance)})
+				}
+			}
+		}
+		return m
+	}
+	for _, p := range pokemon {
+		var found [data.GameLeafGreen + 1]bool
+		for _, loc := range p.Locations {
+			if loc.AreaName == area {
+				found[loc.Game] = true
+			}
+		}
+		var versions []string
+		for v := data.GameRed; v <= data.GameLeafGreen; v++ {
+			if found[v] {
+				versions = append(versions, v.String())
+			}
+		}
+		if len(versions) == 0 {
+			continue
+		}
+		m.entries = append(m.entries, infoEntry{p.ID, fmt.Sprintf("%s  %s", pokemonLabel(p, lang), strings.Join(versions, ", "))})
+	}
+	return m
+}
+
+func (m InfoModel) Init() tea.Cmd { return nil }
+
+// infoBindings lists the bindings an info screen matches.
+func infoBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Enter, &k.Escape}
+}
+
+func (m InfoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, keys.Down):
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, keys.Enter):
+			if m.cursor < len(m.entries) {
+				id := m.entries[m.cursor].id
+				return m, func() tea.Msg { return switchToDetailMsg{pokemonID: id} }
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m InfoModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString("  " + m.title + "\n")
+	for _, line := range m.lines {
+		sb.WriteString("  " + line + "\n")
+	}
+	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)) + "\n")
+
+	if len(m.entries) == 0 {
+		sb.WriteString(dimStyle.Render("  No Pokémon"))
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString(headerStyle.Render(fmt.Sprintf("  %s (%d)", m.listTitle, len(m.entries))) + "\n")
+		visible := max(m.height-8-len(m.lines), maxVisible)
+		start := 0
+		if m.cursor >= visible {
+			start = m.cursor - visible + 1
+		}
+		end := min(start+visible, len(m.entries))
+		for i := start; i < end; i++ {
+			if i == m.cursor {
+				sb.WriteString(selectedRowStyle.Render("  > " + m.entries[i].label))
+			} else {
+				sb.WriteString("    " + m.entries[i].label)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("  %s:back  %s:open  %s:navigate",
+		keyHelp(keys.Escape, false), keyHelp(keys.Enter, false), keysHelp(false, keys.Up, keys.Down))))
+	return sb.String()
+}
+
+// pokemonLabel is the "#025 Pikachu" form used in lists.
+func pokemonLabel(p *data.Pokemon, lang data.Language) string {
+	return fmt.Sprintf("#%03d %s", p.ID, pokemonName(p, lang))
+}
+
+// orDash formats a power or accuracy, where 0 means "not applicable".
+func orDash(v uint8) string {
+	if v == 0 {
+		return "—"
+	}
+	return fmt.Sprint(v)
+}
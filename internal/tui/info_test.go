@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var infoTestPokemon = []*data.Pokemon{
+	{ID: 92, Name: "gastly", Abilities: [2]data.AbilityID{26, 0},
+		Locations: []data.Location{{Game: data.GameBlue, AreaName: "pokemon-tower-3f"}, {Game: data.GameRed, AreaName: "pokemon-tower-3f"}}},
+	{ID: 131, Name: "lapras", Moves: []data.VersionedLearnset{
+		{Version: data.GameRed, Moves: []data.LearnedMove{{MoveID: 57, Method: data.LearnMachine}}},
+		{Version: data.GameGold, Moves: []data.LearnedMove{{MoveID: 57, Method: data.LearnEgg}}},
+	}},
+}
+
+var infoTestSurf = &data.Move{ID: 57, Name: "Surf", Type: data.TypeWater, Category: data.CategorySpecial, Power: 95, Accuracy: 100, PP: 15}
+
+var infoTestLevitate = &data.Ability{ID: 26, Name: "levitate", ShortDesc: "Immune to Ground moves."}
+
+func TestInfoModel_Move(t *testing.T) {
+	setupMovesForTest(t, infoTestSurf)
+	setupAbilitiesForTest(t, infoTestLevitate)
+	m := NewMoveInfoModel(57, infoTestPokemon, data.LangEnglish, 80, 24)
+	view := m.View()
+	for _, want := range []string{"Move: Surf", "Power: 95", "Learned by (1)", "#131 Lapras  TM/HM, Egg"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in move view:\n%s", want, view)
+		}
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(switchToDetailMsg); !ok || msg.pokemonID != 131 {
+		t.Errorf("enter = %#v, want switchToDetailMsg for #131", msg)
+	}
+}
+
+func TestInfoModel_AbilityAndArea(t *testing.T) {
+	setupMovesForTest(t, infoTestSurf)
+	setupAbilitiesForTest(t, infoTestLevitate)
+	view := NewAbilityInfoModel(26, infoTestPokemon, data.LangEnglish, 80, 24).View()
+	if !strings.Contains(view, "Ability: Levitate") || !strings.Contains(view, "#092 Gastly") {
+		t.Errorf("ability view:\n%s", view)
+	}
+	view = NewAreaInfoModel("pokemon-tower-3f", 0, infoTestPokemon, data.LangEnglish, 80, 24).View()
+	if !strings.Contains(view, "Location: Pokemon Tower 3f") || !strings.Contains(view, "#092 Gastly  Red, Blue") {
+		t.Errorf("area view:\n%s", view)
+	}
+}
+
+func TestInfoModel_AreaEncounterTable(t *testing.T) {
+	pokemon := []*data.Pokemon{
+		{ID: 16, Name: "pidgey", Locations: []data.Location{
+			{Game: data.GameRed, AreaName: "route-1", EncounterMethod: data.EncounterWalk, MinLevel: 2, MaxLevel: 5, Chance: 55},
+			{Game: data.GameBlue, AreaName: "route-1", EncounterMethod: data.EncounterWalk, MinLevel: 3, MaxLevel: 4, Chance: 50},
+		}},
+		{ID: 19, Name: "rattata", Locations: []data.Location{
+			{Game: data.GameBlue, AreaName: "route-1", EncounterMethod: data.EncounterWalk, MinLevel: 2, MaxLevel: 4, Chance: 45},
+		}},
+	}
+	view := NewAreaInfoModel("route-1", data.GameRed, pokemon, data.LangEnglish, 80, 24).View()
+	if !strings.Contains(view, "Version: Red") || !strings.Contains(view, "Encounters (1)") {
+		t.Errorf("area view for Red:\n%s", view)
+	}
+	if !strings.Contains(view, "#016 Pidgey") || !strings.Contains(view, "Lv2-5") || !strings.Contains(view, "55%") {
+		t.Errorf("expected Pidgey's Red encounter:\n%s", view)
+	}
+	if strings.Contains(view, "Rattata") {
+		t.Errorf("Rattata isn't in Red's table:\n%s", view)
+	}
+}
+
+func TestSearchModel_EnterRoutesByKind(t *testing.T) {
+	setupMovesForTest(t, infoTestSurf)
+	setupAbilitiesForTest(t, infoTestLevitate)
+	m := NewSearchModel()
+	m.pokemon = infoTestPokemon
+	m.input.SetValue("sur")
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	sm2 := m2.(SearchModel)
+	if !strings.Contains(sm2.View(), "Move Surf") {
+		t.Errorf("expected a Move row for surf:\n%s", sm2.View())
+	}
+	_, cmd := sm2.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(switchToMoveMsg); !ok || msg.moveID != 57 {
+		t.Errorf("enter = %#v, want switchToMoveMsg for Surf", msg)
+	}
+}
@@ -1,16 +1,58 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
-// KeyMap holds all key bindings for the application.
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/config"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// KeyMap holds all key bindings for the application. Each screen matches
+// only the bindings it uses, so one key can do different things on
+// different screens.
 type KeyMap struct {
 	Up       key.Binding
 	Down     key.Binding
+	Left     key.Binding
+	Right    key.Binding
 	Enter    key.Binding
 	Escape   key.Binding
 	Tab      key.Binding
 	ShiftTab key.Binding
 	Quit     key.Binding
+	Toggle   key.Binding // filter panel, type chart and planner checkboxes
+	Clear    key.Binding // filter panel and type chart picks
+
+	// Search screen
+	Track    key.Binding // also on the detail screen
+	Party    key.Binding
+	Planner  key.Binding
+	Language key.Binding
+	Filters  key.Binding
+	Mark     key.Binding
+	Compare  key.Binding
+	Sort     key.Binding // also on the moves screen
+
+	// Moves screen and the detail screen's Moves tab
+	Gen            key.Binding
+	TypeFilter     key.Binding
+	CategoryFilter key.Binding
+	Version        key.Binding
+	HideLevelUp    key.Binding
+	HideMachines   key.Binding
+	HideTutor      key.Binding
+	HideEgg        key.Binding
+
+	// Number keys: versions 1-9 on the detail and compare screens, and
+	// generations 1-3 on the type chart
+	VersionKeys [9]key.Binding
+	GenKeys     [3]key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -23,6 +65,14 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("down", "ctrl+n"),
 		key.WithHelp("↓/ctrl+n", "down"),
 	),
+	Left: key.NewBinding(
+		key.WithKeys("left"),
+		key.WithHelp("←", "left"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right"),
+		key.WithHelp("→", "right"),
+	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "open"),
@@ -43,4 +93,318 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle"),
+	),
+	Clear: key.NewBinding(
+		key.WithKeys("backspace"),
+		key.WithHelp("bksp", "clear"),
+	),
+	Track: key.NewBinding(
+		key.WithKeys("ctrl+x"),
+		key.WithHelp("ctrl+x", "seen/caught"),
+	),
+	Party: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "my Pokémon"),
+	),
+	Planner: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "planner"),
+	),
+	Language: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("ctrl+l", "language"),
+	),
+	Filters: key.NewBinding(
+		key.WithKeys("ctrl+f"),
+		key.WithHelp("ctrl+f", "filters"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "mark"),
+	),
+	Compare: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "compare"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "sort"),
+	),
+	Gen: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "gen"),
+	),
+	TypeFilter: key.NewBinding(
+		key.WithKeys("t", "ctrl+t"),
+		key.WithHelp("t", "type"),
+	),
+	CategoryFilter: key.NewBinding(
+		key.WithKeys("c", "ctrl+y"),
+		key.WithHelp("c", "category"),
+	),
+	Version: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "version"),
+	),
+	HideLevelUp: key.NewBinding(
+		key.WithKeys("l"),
+		key.WithHelp("l", "level-up moves"),
+	),
+	HideMachines: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "TM/HM moves"),
+	),
+	HideTutor: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "tutor moves"),
+	),
+	HideEgg: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "egg moves"),
+	),
+	VersionKeys: [9]key.Binding{
+		versionKey(1), versionKey(2), versionKey(3), versionKey(4), versionKey(5),
+		versionKey(6), versionKey(7), versionKey(8), versionKey(9),
+	},
+	GenKeys: [3]key.Binding{genKey(1), genKey(2), genKey(3)},
+}
+
+// versionKey is the default binding picking version v: its number.
+func versionKey(v data.GameVersion) key.Binding {
+	n := strconv.Itoa(int(v))
+	return key.NewBinding(key.WithKeys(n), key.WithHelp(n, v.String()))
+}
+
+// genKey is the default binding picking generation gen: its number.
+func genKey(gen data.Generation) key.Binding {
+	n := strconv.Itoa(int(gen))
+	return key.NewBinding(key.WithKeys(n), key.WithHelp(n, "Gen "+n))
+}
+
+// numberKey returns which of bs msg matches, counting from 1, or 0 if none.
+func numberKey(msg tea.KeyMsg, bs []key.Binding) int {
+	for i, b := range bs {
+		if key.Matches(msg, b) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// keys is the key map in use; Run replaces it with the user's.
+var keys = DefaultKeyMap
+
+// keyPresets are the bindings a keys file can start from, as changes to
+// DefaultKeyMap by action name.
+var keyPresets = map[string]map[string][]string{
+	"default": nil,
+	"vim": {
+		"up":            {"up", "k", "ctrl+k"},
+		"down":          {"down", "j", "ctrl+j"},
+		"left":          {"left", "h"},
+		"right":         {"right", "l"},
+		"hide-level-up": {"L"},
+	},
+	"emacs": {
+		"left":    {"left", "ctrl+b"},
+		"right":   {"right", "ctrl+f"},
+		"escape":  {"esc", "ctrl+g"},
+		"compare": {"alt+c"},
+		"gen":     {"alt+g"},
+	},
+}
+
+// keyAction is a binding and the name keys files know it by.
+type keyAction struct {
+	name    string
+	binding *key.Binding
+}
+
+// actions lists k's bindings by name, in KeyMap order. The number keys are
+// version-1 to version-9 and gen-1 to gen-3.
+func (k *KeyMap) actions() []keyAction {
+	actions := []keyAction{
+		{"up", &k.Up}, {"down", &k.Down}, {"left", &k.Left}, {"right", &k.Right},
+		{"enter", &k.Enter}, {"escape", &k.Escape}, {"tab", &k.Tab}, {"shift-tab", &k.ShiftTab},
+		{"quit", &k.Quit}, {"toggle", &k.Toggle}, {"clear", &k.Clear},
+		{"track", &k.Track}, {"party", &k.Party}, {"planner", &k.Planner}, {"language", &k.Language},
+		{"filters", &k.Filters}, {"mark", &k.Mark}, {"compare", &k.Compare}, {"sort", &k.Sort},
+		{"gen", &k.Gen}, {"type-filter", &k.TypeFilter}, {"category-filter", &k.CategoryFilter},
+		{"version", &k.Version}, {"hide-level-up", &k.HideLevelUp}, {"hide-machines", &k.HideMachines},
+		{"hide-tutor", &k.HideTutor}, {"hide-egg", &k.HideEgg},
+	}
+	for i := range k.VersionKeys {
+		actions = append(actions, keyAction{fmt.Sprintf("version-%d", i+1), &k.VersionKeys[i]})
+	}
+	for i := range k.GenKeys {
+		actions = append(actions, keyAction{fmt.Sprintf("gen-%d", i+1), &k.GenKeys[i]})
+	}
+	return actions
+}
+
+// binding returns the binding for an action name, or nil.
+func (k *KeyMap) binding(name string) *key.Binding {
+	for _, a := range k.actions() {
+		if a.name == name {
+			return a.binding
+		}
+	}
+	return nil
+}
+
+// name returns the action name of one of k's bindings.
+func (k *KeyMap) name(b *key.Binding) string {
+	for _, a := range k.actions() {
+		if a.binding == b {
+			return a.name
+		}
+	}
+	return ""
+}
+
+// keyContext is the set of bindings one screen, or overlay, matches.
+// Screens with a text input take typed characters as text, so their
+// bindings only act on the other keys.
+type keyContext struct {
+	name   string
+	typing bool
+	// bindings lists the bindings of a key map the screen matches; each is
+	// kept next to the screen's Update.
+	bindings func(k *KeyMap) []*key.Binding
+}
+
+// keyContexts are the screens and overlays Validate checks for conflicts.
+var keyContexts = []keyContext{
+	{name: "search", typing: true, bindings: searchBindings},
+	{name: "filter panel", bindings: filterPanelBindings},
+	{name: "detail", bindings: detailBindings},
+	{name: "version picker", bindings: versionPickerBindings},
+	{name: "moves", typing: true, bindings: movesBindings},
+	{name: "abilities", typing: true, bindings: abilitiesBindings},
+	{name: "type chart", bindings: typeChartBindings},
+	{name: "info", bindings: infoBindings},
+	{name: "party", bindings: partyBindings},
+	{name: "planner", bindings: plannerBindings},
+	{name: "compare", bindings: compareBindings},
+}
+
+// NewKeyMap builds the key map a keys file describes: its preset, then its
+// own bindings, which must name known actions and leave no key doing two
+// things on one screen.
+func NewKeyMap(cfg config.Keys) (KeyMap, error) {
+	k := DefaultKeyMap
+	name := cfg.Preset
+	if name == "" {
+		name = "default"
+	}
+	preset, ok := keyPresets[name]
+	if !ok {
+		return k, fmt.Errorf("unknown key preset %q (want default, vim or emacs)", cfg.Preset)
+	}
+	for _, overrides := range []map[string][]string{preset, cfg.Bindings} {
+		for action, ks := range overrides {
+			b := k.binding(action)
+			if b == nil {
+				return k, fmt.Errorf("unknown key action %q", action)
+			}
+			if len(ks) == 0 {
+				return k, fmt.Errorf("%s: no keys", action)
+			}
+			ks = slices.Clone(ks)
+			for i, s := range ks {
+				if s == "space" {
+					ks[i] = " "
+				}
+			}
+			*b = key.NewBinding(key.WithKeys(ks...), key.WithHelp(ks[0], b.Help().Desc))
+		}
+	}
+	return k, k.Validate()
+}
+
+// Validate reports every key bound to two actions on the same screen, and
+// every action a screen with a text input can't reach because all its keys
+// would be typed into the query.
+func (k KeyMap) Validate() error {
+	var errs []error
+	for _, c := range keyContexts {
+		owner := make(map[string]string)
+		for _, b := range c.bindings(&k) {
+			action := k.name(b)
+			reachable := false
+			for _, s := range b.Keys() {
+				if c.typing && isTypedKey(s) {
+					continue
+				}
+				reachable = true
+				if prev, ok := owner[s]; ok && prev != action {
+					errs = append(errs, fmt.Errorf("%s screen: %q is bound to both %s and %s", c.name, keyLabel(s), prev, action))
+					continue
+				}
+				owner[s] = action
+			}
+			if !reachable {
+				errs = append(errs, fmt.Errorf("%s screen: %s has only keys typed into the query", c.name, action))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// typed reports whether a key is a character a text input takes as text
+// rather than a binding: a letter, digit, symbol or space without alt.
+func typed(msg tea.KeyMsg) bool {
+	return (msg.Type == tea.KeyRunes && !msg.Alt) || msg.Type == tea.KeySpace
+}
+
+// isTypedKey is typed for a key as named in a binding.
+func isTypedKey(s string) bool {
+	return len([]rune(s)) == 1
+}
+
+// keyLabel is how a footer shows a key.
+func keyLabel(s string) string {
+	switch s {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	case "backspace":
+		return "bksp"
+	}
+	return s
+}
+
+// keyHelp is the first key of b a screen acts on, for its footer; on a
+// screen with a text input that skips typed keys.
+func keyHelp(b key.Binding, typing bool) string {
+	for _, s := range b.Keys() {
+		if !typing || !isTypedKey(s) {
+			return keyLabel(s)
+		}
+	}
+	return ""
+}
+
+// numberKeysHelp is the first and last of a run of number keys, as "1-9".
+func numberKeysHelp(bs []key.Binding) string {
+	return keyHelp(bs[0], false) + "-" + keyHelp(bs[len(bs)-1], false)
+}
+
+// keysHelp joins keyHelp for several bindings with "/".
+func keysHelp(typing bool, bs ...key.Binding) string {
+	var names []string
+	for _, b := range bs {
+		names = append(names, keyHelp(b, typing))
+	}
+	return strings.Join(names, "/")
 }
@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/davidlawson7/pokedex/internal/config"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// useKeys swaps in a key map for the rest of the test.
+func useKeys(t *testing.T, k KeyMap) {
+	t.Helper()
+	saved := keys
+	t.Cleanup(func() { keys = saved })
+	keys = k
+}
+
+func TestNewKeyMap_PresetsAreValid(t *testing.T) {
+	for name := range keyPresets {
+		if _, err := NewKeyMap(config.Keys{Preset: name}); err != nil {
+			t.Errorf("preset %s: %v", name, err)
+		}
+	}
+	if err := DefaultKeyMap.Validate(); err != nil {
+		t.Errorf("DefaultKeyMap: %v", err)
+	}
+}
+
+func TestNewKeyMap_Errors(t *testing.T) {
+	tests := []struct {
+		cfg  config.Keys
+		want string
+	}{
+		{config.Keys{Preset: "nano"}, `unknown key preset "nano"`},
+		{config.Keys{Bindings: map[string][]string{"jump": {"J"}}}, `unknown key action "jump"`},
+		{config.Keys{Bindings: map[string][]string{"quit": {}}}, "quit: no keys"},
+		{config.Keys{Bindings: map[string][]string{"version": {"t"}}}, `detail screen: "t" is bound to both version and type-filter`},
+		{config.Keys{Bindings: map[string][]string{"mark": {"ctrl+r"}}}, `search screen: "ctrl+r" is bound to both planner and mark`},
+		{config.Keys{Bindings: map[string][]string{"toggle": {"2"}}}, `type chart screen: "2" is bound to both toggle and gen-2`},
+		{config.Keys{Bindings: map[string][]string{"version-1": {"v"}}}, `detail screen: "v" is bound to both version and version-1`},
+		{config.Keys{Bindings: map[string][]string{"planner": {"p"}}}, "search screen: planner has only keys typed into the query"},
+		{config.Keys{Bindings: map[string][]string{"gen": {"g", "G"}}}, "moves screen: gen has only keys typed into the query"},
+		// vim's "l" moves right, so it can't be the level-up toggle too.
+		{config.Keys{Preset: "vim", Bindings: map[string][]string{"hide-level-up": {"l"}}}, `"l" is bound to both right and hide-level-up`},
+	}
+	for _, tt := range tests {
+		_, err := NewKeyMap(tt.cfg)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewKeyMap(%+v) = %v, want an error containing %q", tt.cfg, err, tt.want)
+		}
+	}
+}
+
+func TestNewKeyMap_TypedKeysDontConflictWhileTyping(t *testing.T) {
+	// "t" is the Moves tab's type filter on the detail screen, and would be
+	// typed into the query on the search screen.
+	if _, err := NewKeyMap(config.Keys{Bindings: map[string][]string{"mark": {"t", "ctrl+t"}}}); err != nil {
+		t.Errorf("unexpected conflict: %v", err)
+	}
+}
+
+func TestKeyMap_VimPresetDrivesScreens(t *testing.T) {
+	k, err := NewKeyMap(config.Keys{Preset: "vim", Bindings: map[string][]string{"toggle": {"space", "x"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	useKeys(t, k)
+	press := func(m tea.Model, s string) tea.Model {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+		if s == "ctrl+j" {
+			msg = tea.KeyMsg{Type: tea.KeyCtrlJ}
+		}
+		next, _ := m.Update(msg)
+		return next
+	}
+
+	m := NewTypeChartModel(80, 24)
+	for _, s := range []string{"j", "j", "l", "x"} {
+		m = press(m, s).(TypeChartModel)
+	}
+	if m.row != 2 || m.col != 1 || m.defender[0] != data.TypesInGen(m.gen)[1] {
+		t.Errorf("after j j l x: row %d, col %d, defender %v", m.row, m.col, m.defender)
+	}
+
+	// On the search screen j is typed, and ctrl+j moves down.
+	s := newTestSearchModel()
+	s.pokemon = testPokemon
+	s.refresh()
+	s = press(s, "j").(SearchModel)
+	if s.input.Value() != "j" || s.cursor != 0 {
+		t.Errorf("j on search: query %q, cursor %d", s.input.Value(), s.cursor)
+	}
+	s.input.SetValue("")
+	s.refresh()
+	if s = press(s, "ctrl+j").(SearchModel); s.cursor != 1 {
+		t.Errorf("ctrl+j on search: cursor %d, want 1", s.cursor)
+	}
+}
+
+func TestKeyMap_NumberKeysCanBeRebound(t *testing.T) {
+	k, err := NewKeyMap(config.Keys{Bindings: map[string][]string{"version-1": {"!"}, "gen-1": {"alt+1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	useKeys(t, k)
+	press := func(m tea.Model, msg tea.KeyMsg) tea.Model {
+		next, _ := m.Update(msg)
+		return next
+	}
+
+	d := buildDetailModel(detailTestBulbasaur)
+	d.selectedVersion = data.GameEmerald
+	if d = press(d, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}}).(DetailModel); d.selectedVersion != data.GameEmerald {
+		t.Errorf("1 still picks %v", d.selectedVersion)
+	}
+	if d = press(d, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}}).(DetailModel); d.selectedVersion != data.GameRed {
+		t.Errorf("! picked %v, want Red", d.selectedVersion)
+	}
+	if v := d.View(); !strings.Contains(v, "v/!-9:version") {
+		t.Errorf("detail footer doesn't show the rebound number keys:\n%s", v)
+	}
+
+	c := NewTypeChartModel(80, 24)
+	if c = press(c, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}, Alt: true}).(TypeChartModel); c.gen != 1 {
+		t.Errorf("alt+1 picked Gen %d, want 1", c.gen)
+	}
+}
+
+// keyContextFiles are the files holding each key context's Update.
+var keyContextFiles = map[string]string{
+	"search":         "search.go",
+	"filter panel":   "filterpanel.go",
+	"detail":         "detail.go",
+	"version picker": "versionpicker.go",
+	"moves":          "moves.go",
+	"abilities":      "abilities.go",
+	"type chart":     "typechart.go",
+	"info":           "info.go",
+	"party":          "party.go",
+	"planner":        "planner.go",
+	"compare":        "compare.go",
+}
+
+// TestKeyContexts_MatchScreens checks each context lists exactly the
+// KeyMap fields its screen's file uses, so Validate sees every binding a
+// screen matches.
+func TestKeyContexts_MatchScreens(t *testing.T) {
+	k := DefaultKeyMap
+	fields := make(map[*key.Binding]string)
+	v := reflect.ValueOf(&k).Elem()
+	for i := range v.NumField() {
+		f := v.Field(i)
+		if b, ok := f.Addr().Interface().(*key.Binding); ok {
+			fields[b] = v.Type().Field(i).Name
+			continue
+		}
+		for j := range f.Len() {
+			fields[f.Index(j).Addr().Interface().(*key.Binding)] = v.Type().Field(i).Name
+		}
+	}
+
+	for _, c := range keyContexts {
+		file, ok := keyContextFiles[c.name]
+		if !ok {
+			t.Errorf("no file for the %s context", c.name)
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(".", file), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var used []string
+		ast.Inspect(f, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok && id.Name == "keys" && !slices.Contains(used, sel.Sel.Name) {
+					used = append(used, sel.Sel.Name)
+				}
+			}
+			return true
+		})
+		var listed []string
+		for _, b := range c.bindings(&k) {
+			if name := fields[b]; !slices.Contains(listed, name) {
+				listed = append(listed, name)
+			}
+		}
+		slices.Sort(used)
+		slices.Sort(listed)
+		if !slices.Equal(used, listed) {
+			t.Errorf("%s context lists %v, but %s uses %v", c.name, listed, file, used)
+		}
+	}
+}
+
+func TestKeyHelp(t *testing.T) {
+	k, err := NewKeyMap(config.Keys{Bindings: map[string][]string{"quit": {"Q", "ctrl+q"}, "up": {"k", "up"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	useKeys(t, k)
+	if got := keyHelp(keys.Quit, true); got != "ctrl+q" {
+		t.Errorf("quit help while typing = %q, want ctrl+q", got)
+	}
+	if got := keyHelp(keys.Up, false); got != "k" {
+		t.Errorf("up help = %q, want k", got)
+	}
+	if got := keyHelp(keys.Up, true); got != "↑" {
+		t.Errorf("up help while typing = %q, want ↑", got)
+	}
+	if v := newTestSearchModel().View(); !strings.Contains(v, "ctrl+q:quit") {
+		t.Errorf("search footer doesn't show the rebound quit key:\n%s", v)
+	}
+}
+
+func TestKeyHelp_MovesFooterFollowsPreset(t *testing.T) {
+	k, err := NewKeyMap(config.Keys{Preset: "emacs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	useKeys(t, k)
+	setupMovesForTest(t, movesTestMoves...)
+	v := ansi.Strip(NewMovesModel(200, 24).View())
+	for _, want := range []string{"esc:back", "alt+g:gen"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("moves footer with the emacs preset lacks %q:\n%s", want, v)
+		}
+	}
+}
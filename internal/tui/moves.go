@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/search"
+)
+
+// moveSort is the column the moves list is sorted by.
+type moveSort byte
+
+const (
+	moveSortMatch    moveSort = 0 // best match first, otherwise by ID
+	moveSortName     moveSort = 1
+	moveSortType     moveSort = 2
+	moveSortCategory moveSort = 3
+	moveSortPower    moveSort = 4
+	moveSortAccuracy moveSort = 5
+	moveSortPP       moveSort = 6
+)
+
+const numMoveSorts = 7
+
+var moveSortNames = [numMoveSorts]string{"Match", "Name", "Type", "Category", "Power", "Accuracy", "PP"}
+
+func (s moveSort) String() string { return moveSortNames[s] }
+
+// nextType steps a type filter through the types in gen, and from the last
+// of them back to TypeNone.
+func nextType(t data.PokeType, gen data.Generation) data.PokeType {
+	types := data.TypesInGen(gen)
+	if t >= types[len(types)-1] {
+		return data.TypeNone
+	}
+	return t + 1
+}
+
+// typeInGen reports whether a type filter can match a move in gen.
+func typeInGen(t data.PokeType, gen data.Generation) bool {
+	types := data.TypesInGen(gen)
+	return t <= types[len(types)-1]
+}
+
+// MovesModel lists every move with its type, category, power, accuracy and
+// PP in the selected generation, filtered by name, type and category.
+type MovesModel struct {
+	input    textinput.Model
+	searcher *search.Searcher // over every move, built once
+	results  []search.Result
+	gen      data.Generation
+	sort     moveSort
+	typ      data.PokeType // TypeNone shows every type
+	category int           // 0 shows every category, else MoveCategory+1
+	cursor   int
+	lang     data.Language // names are shown in this language
+	width    int
+	height   int
+}
+
+// NewMovesModel creates a moves list for Gen 3, sorted by ID.
+func NewMovesModel(width, height int) MovesModel {
+	ti := textinput.New()
+	ti.Placeholder = "move name"
+	ti.Focus()
+
+	var moves []*data.Move
+	for _, mv := range data.AllMoves {
+		if mv != nil {
+			moves = append(moves, mv)
+		}
+	}
+	c := &search.Corpus{Moves: moves}
+	m := MovesModel{input: ti, searcher: search.NewSearcher(c.Index()), gen: 3, width: width, height: height}
+	m.refresh()
+	return m
+}
+
+func (m MovesModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// movesBindings lists the bindings the moves list matches.
+func movesBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Enter, &k.Escape, &k.Tab, &k.Quit, &k.Gen, &k.Sort,
+		&k.TypeFilter, &k.CategoryFilter}
+}
+
+func (m MovesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if typed(msg) {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Tab):
+			return m, func() tea.Msg { return switchToAbilitiesMsg{} }
+
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Down):
+			if m.cursor < len(m.results)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Gen):
+			m.gen = m.gen%3 + 1
+			if !typeInGen(m.typ, m.gen) {
+				m.typ = data.TypeNone
+			}
+			m.refresh()
+			return m, nil
+
+		case key.Matches(msg, keys.Sort):
+			m.sort = (m.sort + 1) % numMoveSorts
+			m.refresh()
+			return m, nil
+
+		case key.Matches(msg, keys.TypeFilter):
+			m.typ = nextType(m.typ, m.gen)
+			m.refresh()
+			return m, nil
+
+		case key.Matches(msg, keys.CategoryFilter):
+			m.category = (m.category + 1) % 4
+			m.refresh()
+			return m, nil
+
+		case key.Matches(msg, keys.Enter):
+			if m.cursor < len(m.results) {
+				id := m.results[m.cursor].Move.ID
+				return m, func() tea.Msg { return switchToMoveMsg{moveID: id} }
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.refresh()
+	return m, cmd
+}
+
+// refresh re-filters and re-sorts the list, keeping the moves introduced by
+// the selected generation, and clamps the cursor.
+func (m *MovesModel) refresh() {
+	m.results = nil
+	for _, r := range m.searcher.Filter(search.KindMove, m.input.Value()) {
+		if r.Move.IntroducedIn() > m.gen {
+			continue
+		}
+		if m.typ != data.TypeNone && r.Move.TypeForGen(m.gen) != m.typ {
+			continue
+		}
+		if m.category != 0 && r.Move.CategoryForGen(m.gen) != data.MoveCategory(m.category-1) {
+			continue
+		}
+		m.results = append(m.results, r)
+	}
+	m.sortResults()
+
+	if m.cursor >= len(m.results) {
+		m.cursor = max(len(m.results)-1, 0)
+	}
+}
+
+// sortResults orders the results by the sort column: names, types and
+// categories ascending, numbers highest first, ties keeping match order.
+// Moves that never miss have no accuracy and sort as the most accurate.
+func (m *MovesModel) sortResults() {
+	key := func(mv *data.Move) int {
+		power, accuracy, pp := mv.ValuesForGen(m.gen)
+		switch m.sort {
+		case moveSortType:
+			return int(mv.TypeForGen(m.gen))
+		case moveSortCategory:
+			return int(mv.CategoryForGen(m.gen))
+		case moveSortPower:
+			return -int(power)
+		case moveSortAccuracy:
+			if accuracy == 0 {
+				return -101
+			}
+			return -int(accuracy)
+		case moveSortPP:
+			return -int(pp)
+		}
+		return 0
+	}
+	switch m.sort {
+	case moveSortMatch:
+	case moveSortName:
+		sort.SliceStable(m.results, func(i, j int) bool {
+			return moveName(m.results[i].Move, m.lang) < moveName(m.results[j].Move, m.lang)
+		})
+	default:
+		sort.SliceStable(m.results, func(i, j int) bool {
+			return key(m.results[i].Move) < key(m.results[j].Move)
+		})
+	}
+}
+
+func (m MovesModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString("  Moves: ")
+	sb.WriteString(m.input.View())
+	sb.WriteString("\n")
+	typ, category := "Any", "Any"
+	if m.typ != data.TypeNone {
+		typ = m.typ.String()
+	}
+	if m.category != 0 {
+		category = data.MoveCategory(m.category - 1).String()
+	}
+	sb.WriteString(dimStyle.Render(fmt.Sprintf("  Gen %d  Sort: %s  Type: %s  Category: %s  (%d moves)",
+		m.gen, m.sort, typ, category, len(m.results))) + "\n")
+	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)))
+	sb.WriteString("\n")
+
+	if len(m.results) == 0 {
+		sb.WriteString(dimStyle.Render("  No moves") + "\n")
+	} else {
+		sb.WriteString(headerStyle.Render(fmt.Sprintf("    %-16s %-8s %-5s %3s %3s %3s",
+			"Name", "Type", "Cat", "Pwr", "Acc", "PP")) + "\n")
+		start := 0
+		if m.cursor >= maxVisible {
+			start = m.cursor - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(m.results))
+		for i := start; i < end; i++ {
+			if i == m.cursor {
+				sb.WriteString(selectedRowStyle.Render("  > ") + m.formatRow(m.results[i], selectedRowStyle))
+			} else {
+				sb.WriteString("    " + m.formatRow(m.results[i], lipgloss.NewStyle()))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	help := func(b key.Binding) string { return keyHelp(b, true) }
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("  %s:back  %s:history  %s%s:navigate  %s:gen  %s:sort  %s:type  "+
+		"%s:category  %s:abilities", help(keys.Escape), help(keys.Enter), help(keys.Up), help(keys.Down), help(keys.Gen),
+		help(keys.Sort), help(keys.TypeFilter), help(keys.CategoryFilter), help(keys.Tab))))
+	return sb.String()
+}
+
+// formatRow renders a move's name, with the matched characters highlighted,
+// and its stats in the selected generation.
+func (m MovesModel) formatRow(r search.Result, base lipgloss.Style) string {
+	name, positions := resultName(r, m.lang)
+	mv := r.Move
+	power, accuracy, pp := mv.ValuesForGen(m.gen)
+	return highlight(name, positions, 16, base) + base.Render(fmt.Sprintf(" %-8s %-5s %3s %3s %3d",
+		mv.TypeForGen(m.gen), mv.CategoryForGen(m.gen), orDash(power), orDash(accuracy), pp))
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var movesTestMoves = []*data.Move{
+	{ID: 33, Name: "Tackle", Type: data.TypeNormal, Category: data.CategoryPhysical, Power: 40, Accuracy: 100, PP: 35,
+		PastValues: []data.MoveValuesPast{{UntilGen: 3, Power: 35, Accuracy: 95}, {UntilGen: 3, Power: 50}}},
+	detailTestBite,
+	{ID: 53, Name: "Flamethrower", Type: data.TypeFire, Category: data.CategorySpecial,
+		Power: 90, Accuracy: 100, PP: 15, PastValues: []data.MoveValuesPast{{UntilGen: 3, Power: 95}}},
+	{ID: 57, Name: "Surf", Type: data.TypeWater, Category: data.CategorySpecial,
+		Power: 90, Accuracy: 100, PP: 15, PastValues: []data.MoveValuesPast{{UntilGen: 3, Power: 95}}},
+	{ID: 252, Name: "Fake Out", Type: data.TypeNormal, Category: data.CategoryPhysical, Power: 40, Accuracy: 100, PP: 10},
+}
+
+// moveNames lists the names in the moves list, in order.
+func moveNames(m MovesModel) []string {
+	var names []string
+	for _, r := range m.results {
+		names = append(names, r.Move.Name)
+	}
+	return names
+}
+
+func TestMovesModel_ListsGenValues(t *testing.T) {
+	setupMovesForTest(t, movesTestMoves...)
+	m := NewMovesModel(80, 24)
+	if got := strings.Join(moveNames(m), ","); got != "Tackle,Bite,Flamethrower,Surf,Fake Out" {
+		t.Errorf("Gen 3 moves = %s", got)
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{
+		"Tackle           Normal   Phys   35  95  35",
+		"Bite             Dark     Phys   60 100  25",
+		"Surf             Water    Spec   95 100  15",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in moves view:\n%s", want, view)
+		}
+	}
+
+	// Gen 1 drops Fake Out and has Bite as Normal.
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	m = next.(MovesModel)
+	if m.gen != 1 || len(m.results) != 4 {
+		t.Fatalf("after ctrl+g gen = %d, moves = %v", m.gen, moveNames(m))
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Bite             Normal   Phys   60 100  25") {
+		t.Errorf("expected Gen 1 Bite in moves view:\n%s", view)
+	}
+}
+
+func TestMovesModel_FilterAndSort(t *testing.T) {
+	setupMovesForTest(t, movesTestMoves...)
+	m := NewMovesModel(80, 24)
+	update := func(msgs ...tea.Msg) {
+		for _, msg := range msgs {
+			next, _ := m.Update(msg)
+			m = next.(MovesModel)
+		}
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("surf")})
+	if got := moveNames(m); len(got) == 0 || got[0] != "Surf" {
+		t.Errorf("\"surf\" = %v, want Surf first", got)
+	}
+	update(tea.KeyMsg{Type: tea.KeyCtrlU})
+
+	update(tea.KeyMsg{Type: tea.KeyCtrlY}, tea.KeyMsg{Type: tea.KeyCtrlY})
+	if got := strings.Join(moveNames(m), ","); got != "Flamethrower,Surf" {
+		t.Errorf("special moves = %s", got)
+	}
+	update(tea.KeyMsg{Type: tea.KeyCtrlY}, tea.KeyMsg{Type: tea.KeyCtrlY})
+
+	// Sort by power, highest first, ties keeping ID order; Tackle was 35
+	// in Gen 3.
+	for m.sort != moveSortPower {
+		update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	}
+	if got := strings.Join(moveNames(m), ","); got != "Flamethrower,Surf,Bite,Fake Out,Tackle" {
+		t.Errorf("moves by power = %s", got)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if got := strings.Join(moveNames(m), ","); got != "Fake Out,Tackle" {
+		t.Errorf("normal moves by power = %s", got)
+	}
+}
+
+func TestMovesModel_EnterOpensHistory(t *testing.T) {
+	setupMovesForTest(t, movesTestMoves...)
+	a := NewAppModel()
+	next, _ := a.Update(switchToMovesMsg{})
+	a = next.(AppModel)
+	next, _ = a.Update(tea.KeyMsg{Type: tea.KeyDown})
+	a = next.(AppModel)
+
+	_, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	next, _ = a.Update(cmd())
+	a = next.(AppModel)
+	if a.current != screenInfo {
+		t.Fatalf("enter opened screen %d, want the move", a.current)
+	}
+	view := ansi.Strip(a.View())
+	for _, want := range []string{"Move: Bite", "Gen 1  Normal   Phys   60 100  25", "Gen 3  Dark     Phys   60 100  25"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in move view:\n%s", want, view)
+		}
+	}
+
+	// Esc goes back to the list where it was.
+	_, cmd = a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	next, _ = a.Update(cmd())
+	a = next.(AppModel)
+	if a.current != screenMoves || a.moves.cursor != 1 {
+		t.Errorf("esc went to screen %d, cursor %d; want the moves list at 1", a.current, a.moves.cursor)
+	}
+}
+
+func TestMovesModel_NeverMissSortsMostAccurate(t *testing.T) {
+	swift := &data.Move{ID: 129, Name: "Swift", Type: data.TypeNormal, Category: data.CategoryPhysical, Power: 60, PP: 20}
+	setupMovesForTest(t, append(movesTestMoves, swift)...)
+	m := NewMovesModel(80, 24)
+	for m.sort != moveSortAccuracy {
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+		m = next.(MovesModel)
+	}
+	if got := moveNames(m); got[0] != "Swift" {
+		t.Errorf("moves by accuracy = %v, want Swift first", got)
+	}
+}
+
+func TestAppModel_MovesKeepQueryWithNoResults(t *testing.T) {
+	setupMovesForTest(t, movesTestMoves...)
+	a := NewAppModel()
+	a = send(a, switchToMovesMsg{})
+	a = send(a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("zzz")})
+	if len(a.moves.results) != 0 {
+		t.Fatalf("\"zzz\" = %v, want no moves", moveNames(a.moves))
+	}
+	a = send(a, switchToTypeChartMsg{})
+	a = send(a, switchToMovesMsg{})
+	if got := a.moves.input.Value(); got != "zzz" {
+		t.Errorf("query after coming back = %q, want \"zzz\"", got)
+	}
+}
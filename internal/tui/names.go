@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// localName returns names in lang, the display language a screen holds, or
+// "" for English or a missing name. English keeps the dex names,
+// capitalized; other languages fall back to them when the data has no
+// localized name.
+func localName(names data.LocalNames, lang data.Language) string {
+	if lang == data.LangEnglish {
+		return ""
+	}
+	return names[lang]
+}
+
+// pokemonName is the display name of a species.
+func pokemonName(p *data.Pokemon, lang data.Language) string {
+	if n := localName(p.Names, lang); n != "" {
+		return n
+	}
+	return capitalize(p.Name)
+}
+
+// moveName is the display name of a move in tables.
+func moveName(mv *data.Move, lang data.Language) string {
+	if n := localName(mv.Names, lang); n != "" {
+		return n
+	}
+	return mv.Name
+}
+
+// padRight pads s with spaces to width terminal cells, so names in wide
+// scripts such as kana line up with Latin ones.
+func padRight(s string, width int) string {
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
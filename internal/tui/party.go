@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/save"
+)
+
+// partyEntry is one row of the "my Pokémon" list.
+type partyEntry struct {
+	where string // "Party" or "Box N"
+	mon   save.Pokemon
+}
+
+// PartyModel lists the party and boxed Pokémon read from a save file.
+type PartyModel struct {
+	save    *save.Save
+	entries []partyEntry
+	cursor  int
+	lang    data.Language // names are shown in this language
+	width   int
+	height  int
+}
+
+// NewPartyModel creates the list for a loaded save.
+func NewPartyModel(s *save.Save, width, height int) PartyModel {
+	var entries []partyEntry
+	for _, p := range s.Party {
+		entries = append(entries, partyEntry{where: "Party", mon: p})
+	}
+	for i, box := range s.Boxes {
+		for _, p := range box {
+			entries = append(entries, partyEntry{where: fmt.Sprintf("Box %d", i+1), mon: p})
+		}
+	}
+	return PartyModel{save: s, entries: entries, width: width, height: height}
+}
+
+func (m PartyModel) Init() tea.Cmd { return nil }
+
+// partyBindings lists the bindings the party list matches.
+func partyBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Enter, &k.Escape}
+}
+
+func (m PartyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, keys.Down):
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, keys.Enter):
+			if m.cursor < len(m.entries) {
+				mon := m.entries[m.cursor].mon
+				return m, func() tea.Msg { return switchToDetailMsg{pokemonID: mon.Species, individual: &mon} }
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m PartyModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("  My Pokémon — %s (ID %05d), %s\n", m.save.Trainer, m.save.TrainerID, m.save.Game))
+	sb.WriteString(fmt.Sprintf("  Pokédex: %d owned, %d seen\n", len(m.save.Owned), len(m.save.Seen)))
+	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)) + "\n")
+
+	if len(m.entries) == 0 {
+		sb.WriteString(dimStyle.Render("  No Pokémon in this save"))
+		sb.WriteString("\n")
+	} else {
+		visible := max(m.height-7, maxVisible)
+		start := 0
+		if m.cursor >= visible {
+			start = m.cursor - visible + 1
+		}
+		end := start + visible
+		if end > len(m.entries) {
+			end = len(m.entries)
+		}
+		for i := start; i < end; i++ {
+			line := formatPartyEntry(m.entries[i], m.lang)
+			if i == m.cursor {
+				sb.WriteString(selectedRowStyle.Render("  > " + line))
+			} else {
+				sb.WriteString("    " + line)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("  %s:back  %s:open  %s:navigate",
+		keyHelp(keys.Escape, false), keyHelp(keys.Enter, false), keysHelp(false, keys.Up, keys.Down))))
+	return sb.String()
+}
+
+func formatPartyEntry(e partyEntry, lang data.Language) string {
+	species := fmt.Sprintf("#%03d", e.mon.Species)
+	if p := e.mon.Data(); p != nil {
+		species += " " + pokemonName(p, lang)
+	}
+	level := fmt.Sprintf("Lv%3d", e.mon.Level)
+	if e.mon.IsEgg {
+		level = "Egg  "
+	}
+	return fmt.Sprintf("%-7s %-10s %s  %s", e.where, e.mon.Nickname, level, species)
+}
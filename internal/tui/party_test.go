@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/save"
+)
+
+var partyTestSave = &save.Save{
+	Game:      "Red/Blue/Yellow",
+	Gen:       1,
+	Trainer:   "RED",
+	TrainerID: 12345,
+	Owned:     []uint16{25},
+	Seen:      []uint16{25, 151},
+	Party:     []save.Pokemon{{Species: 25, Nickname: "SPARKY", Level: 25}},
+	Boxes:     [][]save.Pokemon{{{Species: 151, Nickname: "MEW", Level: 5}}},
+}
+
+func TestPartyModel_ListsPartyAndBoxes(t *testing.T) {
+	m := NewPartyModel(partyTestSave, 80, 24)
+	view := m.View()
+	for _, want := range []string{"RED", "1 owned, 2 seen", "Party", "SPARKY", "Box 1", "MEW"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in party view", want)
+		}
+	}
+}
+
+func TestPartyModel_EnterOpensDetail(t *testing.T) {
+	m := NewPartyModel(partyTestSave, 80, 24)
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m2.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected cmd on enter, got nil")
+	}
+	msg, ok := cmd().(switchToDetailMsg)
+	if !ok || msg.pokemonID != 151 || msg.individual == nil || msg.individual.Nickname != "MEW" {
+		t.Errorf("got %#v, want switchToDetailMsg for #151", msg)
+	}
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/planner"
+)
+
+// PlannerModel is the cross-version completion planner screen.
+type PlannerModel struct {
+	pokemon  []*data.Pokemon
+	selected [data.GameLeafGreen + 1]bool
+	cursor   data.GameVersion
+	lines    []string
+	scroll   int
+	width    int
+	height   int
+}
+
+// NewPlannerModel creates a planner comparing Red and Blue.
+func NewPlannerModel(width, height int) PlannerModel {
+	m := PlannerModel{
+		pokemon: data.AllPokemon,
+		cursor:  data.GameRed,
+		width:   width,
+		height:  height,
+	}
+	m.selected[data.GameRed] = true
+	m.selected[data.GameBlue] = true
+	m.rebuild()
+	return m
+}
+
+func (m PlannerModel) Init() tea.Cmd { return nil }
+
+// versions returns the selected versions in release order.
+func (m PlannerModel) versions() []data.GameVersion {
+	var vs []data.GameVersion
+	for v := data.GameRed; v <= data.GameLeafGreen; v++ {
+		if m.selected[v] {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// rebuild recomputes the report lines for the current selection.
+func (m *PlannerModel) rebuild() {
+	m.lines = m.lines[:0]
+	m.scroll = 0
+	vs := m.versions()
+	if len(vs) == 0 {
+		return
+	}
+	r := planner.Build(m.pokemon, vs)
+	for _, s := range r.Sections() {
+		m.lines = append(m.lines, headerStyle.Render(fmt.Sprintf("  %s (%d)", s.Title, len(s.Pokemon))))
+		for _, p := range s.Pokemon {
+			m.lines = append(m.lines, "    [ ] "+planner.Label(p))
+		}
+		m.lines = append(m.lines, "")
+	}
+}
+
+// plannerBindings lists the bindings the planner matches.
+func plannerBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Left, &k.Right, &k.Escape, &k.Toggle}
+}
+
+func (m PlannerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Left):
+			if m.cursor > data.GameRed {
+				m.cursor--
+			}
+
+		case key.Matches(msg, keys.Right):
+			if m.cursor < data.GameLeafGreen {
+				m.cursor++
+			}
+
+		case key.Matches(msg, keys.Toggle):
+			m.selected[m.cursor] = !m.selected[m.cursor]
+			m.rebuild()
+
+		case key.Matches(msg, keys.Up):
+			if m.scroll > 0 {
+				m.scroll--
+			}
+
+		case key.Matches(msg, keys.Down):
+			if m.scroll < len(m.lines)-1 {
+				m.scroll++
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m PlannerModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString("  Completion planner\n")
+	sb.WriteString("  ")
+	for v := data.GameRed; v <= data.GameLeafGreen; v++ {
+		box := "[ ]"
+		if m.selected[v] {
+			box = "[x]"
+		}
+		label := box + " " + v.String()
+		if v == m.cursor {
+			label = selectedRowStyle.Render(label)
+		}
+		sb.WriteString(label + " ")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)) + "\n")
+
+	if len(m.lines) == 0 {
+		sb.WriteString(dimStyle.Render("  Select at least one version"))
+		sb.WriteString("\n")
+	} else {
+		visible := max(m.height-6, maxVisible)
+		end := m.scroll + visible
+		if end > len(m.lines) {
+			end = len(m.lines)
+		}
+		for _, line := range m.lines[m.scroll:end] {
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	help := func(b key.Binding) string { return keyHelp(b, false) }
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("  %s:back  %s%s:version  %s:toggle  %s%s:scroll",
+		help(keys.Escape), help(keys.Left), help(keys.Right), help(keys.Toggle), help(keys.Up), help(keys.Down))))
+	return sb.String()
+}
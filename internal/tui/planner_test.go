@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+var plannerTestPokemon = []*data.Pokemon{
+	{ID: 23, Name: "ekans", Locations: []data.Location{{Game: data.GameRed, AreaName: "route-4"}}},
+	{ID: 27, Name: "sandshrew", Locations: []data.Location{{Game: data.GameBlue, AreaName: "route-4"}}},
+	{ID: 65, Name: "alakazam", EvolvesFrom: 64, EvolutionTrigger: data.EvolveTrade},
+}
+
+func newTestPlannerModel() PlannerModel {
+	m := NewPlannerModel(80, 40)
+	m.pokemon = plannerTestPokemon
+	m.rebuild()
+	return m
+}
+
+func TestPlannerModel_DefaultRedVsBlue(t *testing.T) {
+	m := newTestPlannerModel()
+	view := m.View()
+	for _, want := range []string{"Red only (1)", "#023 Ekans", "Blue only (1)", "#027 Sandshrew", "#065 Alakazam (Trade)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in planner view", want)
+		}
+	}
+}
+
+func TestPlannerModel_ToggleVersion(t *testing.T) {
+	m := newTestPlannerModel()
+	// Cursor starts on Red; move to Blue and deselect it.
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m3, _ := m2.(PlannerModel).Update(tea.KeyMsg{Type: tea.KeySpace})
+	pm := m3.(PlannerModel)
+	if pm.selected[data.GameBlue] {
+		t.Fatal("expected Blue to be deselected")
+	}
+	if strings.Contains(pm.View(), "Blue only") {
+		t.Error("Blue section should be gone after deselecting Blue")
+	}
+}
+
+func TestPlannerModel_EscapeEmitsBackMsg(t *testing.T) {
+	m := newTestPlannerModel()
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("expected cmd on esc, got nil")
+	}
+	if _, ok := cmd().(backMsg); !ok {
+		t.Error("expected backMsg")
+	}
+}
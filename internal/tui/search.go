@@ -2,44 +2,60 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/davidlawson7/pokedex/internal/data"
 	"github.com/davidlawson7/pokedex/internal/search"
+	"github.com/davidlawson7/pokedex/internal/tracker"
 )
 
 const maxVisible = 12
 
 // SearchModel is the fuzzy search screen model.
 type SearchModel struct {
-	input   textinput.Model
-	pokemon []*data.Pokemon
-	results []*data.Pokemon
-	cursor  int
-	width   int
-	height  int
+	input    textinput.Model
+	pokemon  []*data.Pokemon
+	results  []search.Result
+	searcher *search.Searcher // indexes pokemon; rebuilt when the list changes
+	sort     search.SortMode  // applied on top of the search's ranking
+	panel    filterPanel      // checkbox filters, combined with the query
+	compare  []uint16         // Pokémon marked for the compare screen, in order
+	cursor   int
+	tracker  *tracker.Tracker // nil disables seen/caught marking
+	err      error            // last tracker save error, shown in the footer
+	hasSave  bool             // a save file is loaded, so ctrl+o opens "my Pokémon"
+	queryErr error            // query parse error, shown under the input
+	lang     data.Language    // names are shown and sorted in this language
+	width    int
+	height   int
 }
 
 // NewSearchModel creates a new search screen model.
 func NewSearchModel() SearchModel {
 	ti := textinput.New()
-	ti.Placeholder = "Search Pokémon..."
+	ti.Placeholder = "Pokémon, moves, abilities, places... (e.g. type:fire atk>=100)"
 	ti.Focus()
 
-	all := search.Filter("")
-	return SearchModel{
-		input:   ti,
-		pokemon: data.AllPokemon,
-		results: all,
-	}
+	m := SearchModel{input: ti, pokemon: data.AllPokemon}
+	m.results, _ = m.filter("")
+	return m
 }
 
 func (m SearchModel) Init() tea.Cmd {
 	return textinput.Blink
 }
 
+// searchBindings lists the bindings the search screen matches.
+func searchBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Enter, &k.Tab, &k.Quit, &k.Track, &k.Party, &k.Planner,
+		&k.Language, &k.Filters, &k.Mark, &k.Compare, &k.Sort}
+}
+
 func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -48,26 +64,78 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.panel.open && !(key.Matches(msg, keys.Quit) && !typed(msg)) {
+			if m.panel.update(msg) {
+				m.refresh()
+			}
+			return m, nil
+		}
+		if typed(msg) {
+			break
+		}
 		switch {
-		case msg.Type == tea.KeyCtrlC || (msg.Type == tea.KeyRunes && string(msg.Runes) == "q"):
+		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
 
-		case msg.Type == tea.KeyUp || (msg.Type == tea.KeyCtrlP):
+		case key.Matches(msg, keys.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
 			return m, nil
 
-		case msg.Type == tea.KeyDown || (msg.Type == tea.KeyCtrlN):
+		case key.Matches(msg, keys.Down):
 			if m.cursor < len(m.results)-1 {
 				m.cursor++
 			}
 			return m, nil
 
-		case msg.Type == tea.KeyEnter:
-			if len(m.results) > 0 && m.cursor < len(m.results) {
-				id := m.results[m.cursor].ID
-				return m, func() tea.Msg { return switchToDetailMsg{pokemonID: id} }
+		case key.Matches(msg, keys.Track):
+			if m.tracker != nil && m.cursor < len(m.results) && m.results[m.cursor].Kind == search.KindPokemon {
+				m.tracker.Cycle(m.results[m.cursor].Pokemon.ID)
+				m.err = m.tracker.Save()
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Party):
+			if m.hasSave {
+				return m, func() tea.Msg { return switchToPartyMsg{} }
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Planner):
+			return m, func() tea.Msg { return switchToPlannerMsg{} }
+
+		case key.Matches(msg, keys.Tab):
+			return m, func() tea.Msg { return switchToMovesMsg{} }
+
+		case key.Matches(msg, keys.Language):
+			lang := (m.lang + 1) % data.NumLanguages
+			return m, func() tea.Msg { return setLanguageMsg{lang: lang} }
+
+		case key.Matches(msg, keys.Filters):
+			m.panel.open = true
+			return m, nil
+
+		case key.Matches(msg, keys.Mark):
+			if m.cursor < len(m.results) && m.results[m.cursor].Kind == search.KindPokemon {
+				m.toggleCompare(m.results[m.cursor].Pokemon.ID)
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Compare):
+			if len(m.compare) >= 2 {
+				ids := slices.Clone(m.compare)
+				return m, func() tea.Msg { return switchToCompareMsg{ids: ids} }
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Sort):
+			mode := (m.sort + 1) % search.NumSortModes
+			return m, func() tea.Msg { return setSortMsg{mode: mode} }
+
+		case key.Matches(msg, keys.Enter):
+			if m.cursor < len(m.results) {
+				return m, openResult(m.results[m.cursor])
 			}
 			return m, nil
 		}
@@ -77,9 +145,29 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	m.input, cmd = m.input.Update(msg)
 
-	// Re-filter based on updated query
-	query := m.input.Value()
-	m.results = filterPokemon(m.pokemon, query)
+	m.refresh()
+	return m, cmd
+}
+
+// toggleCompare marks id for comparison, or unmarks it if it already is.
+// Marks past maxCompare are ignored.
+func (m *SearchModel) toggleCompare(id uint16) {
+	if i := slices.Index(m.compare, id); i >= 0 {
+		m.compare = slices.Delete(slices.Clone(m.compare), i, i+1)
+	} else if len(m.compare) < maxCompare {
+		m.compare = append(slices.Clone(m.compare), id)
+	}
+}
+
+// refresh re-runs the query in the input; on a parse error it keeps the
+// last results.
+func (m *SearchModel) refresh() {
+	if results, err := m.filter(m.input.Value()); err != nil {
+		m.queryErr = err
+	} else {
+		m.queryErr = nil
+		m.results = results
+	}
 
 	// Clamp cursor
 	if m.cursor >= len(m.results) {
@@ -89,16 +177,45 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursor = 0
 		}
 	}
+}
 
-	return m, cmd
+// filter parses query and searches the Pokémon list plus the move, ability
+// and location tables with it and the panel's filters, in the chosen sort
+// order.
+func (m *SearchModel) filter(query string) ([]search.Result, error) {
+	q, err := search.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	q.AddFacets(m.panel.facets)
+	if m.searcher == nil || !samePokemon(m.searcher.Index().Corpus().Pokemon, m.pokemon) {
+		m.searcher = search.NewSearcher(search.NewCorpus(m.pokemon).Index())
+	}
+	results := m.searcher.Search(q)
+	search.SortResults(results, m.sort, m.lang)
+	return results, nil
 }
 
-// filterPokemon filters the pokemon list using the search package.
-func filterPokemon(pokemon []*data.Pokemon, query string) []*data.Pokemon {
-	// Use the search package's filterOver via the exported Filter,
-	// but we want to filter over the given slice (not data.AllPokemon).
-	// Re-use the score logic inline for flexibility.
-	return search.FilterOver(pokemon, query)
+// samePokemon reports whether a and b are the same slice.
+func samePokemon(a, b []*data.Pokemon) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// openResult routes a result to its detail screen.
+func openResult(r search.Result) tea.Cmd {
+	switch r.Kind {
+	case search.KindMove:
+		id := r.Move.ID
+		return func() tea.Msg { return switchToMoveMsg{moveID: id} }
+	case search.KindAbility:
+		id := r.Ability.ID
+		return func() tea.Msg { return switchToAbilityMsg{abilityID: id} }
+	case search.KindLocation:
+		area := r.Area.Name
+		return func() tea.Msg { return switchToAreaMsg{area: area} }
+	}
+	id := r.Pokemon.ID
+	return func() tea.Msg { return switchToDetailMsg{pokemonID: id} }
 }
 
 func (m SearchModel) View() string {
@@ -107,40 +224,152 @@ func (m SearchModel) View() string {
 	sb.WriteString("  Search: ")
 	sb.WriteString(m.input.View())
 	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("  Sort: "+m.sort.String()+"  [ctrl+s]") + "\n")
+	if m.queryErr != nil {
+		sb.WriteString(errorStyle.Render("  ⚠ "+m.queryErr.Error()) + "\n")
+	}
+	if m.tracker != nil {
+		sb.WriteString(m.renderProgress())
+	}
 	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)))
 	sb.WriteString("\n")
+	if chips := m.panel.chips(); chips != "" {
+		sb.WriteString(chips + "\n")
+	}
+	if m.panel.open {
+		sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, m.panel.View(), m.renderResults()))
+	} else {
+		sb.WriteString(m.renderResults())
+	}
 
+	sb.WriteString("\n")
+	help := func(b key.Binding) string { return keyHelp(b, true) }
+	footer := fmt.Sprintf("  %s:open  %s%s:navigate", help(keys.Enter), help(keys.Up), help(keys.Down))
+	if m.tracker != nil {
+		footer += fmt.Sprintf("  %s:seen/caught", help(keys.Track))
+	}
+	footer += fmt.Sprintf("  %s:planner  %s:quit", help(keys.Planner), help(keys.Quit))
+	if m.hasSave {
+		footer += fmt.Sprintf("  %s:my Pokémon", help(keys.Party))
+	}
+	footer += fmt.Sprintf("  %s:moves  %s:filters  %s:mark %d/%d",
+		help(keys.Tab), help(keys.Filters), help(keys.Mark), len(m.compare), maxCompare)
+	if len(m.compare) >= 2 {
+		footer += fmt.Sprintf("  %s:compare", help(keys.Compare))
+	}
+	footer += fmt.Sprintf("  %s:%s", help(keys.Language), m.lang)
+	sb.WriteString(footerStyle.Render(footer))
+	if m.err != nil {
+		sb.WriteString("\n  " + m.err.Error())
+	}
+	return sb.String()
+}
+
+// renderResults renders the visible window of result rows.
+func (m SearchModel) renderResults() string {
 	if len(m.results) == 0 {
-		sb.WriteString(dimStyle.Render("  No results"))
-		sb.WriteString("\n")
-	} else {
-		start := 0
-		if m.cursor >= maxVisible {
-			start = m.cursor - maxVisible + 1
-		}
-		end := start + maxVisible
-		if end > len(m.results) {
-			end = len(m.results)
+		return dimStyle.Render("  No results") + "\n"
+	}
+	var sb strings.Builder
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := start + maxVisible
+	if end > len(m.results) {
+		end = len(m.results)
+	}
+	for i := start; i < end; i++ {
+		r := m.results[i]
+		mark := " "
+		if r.Kind == search.KindPokemon && slices.Contains(m.compare, r.Pokemon.ID) {
+			mark = "+"
 		}
-		for i := start; i < end; i++ {
-			p := m.results[i]
-			line := formatSearchResult(p)
-			if i == m.cursor {
-				sb.WriteString(selectedRowStyle.Render("  > " + line))
-			} else {
-				sb.WriteString("    " + line)
-			}
-			sb.WriteString("\n")
+		if i == m.cursor {
+			sb.WriteString(selectedRowStyle.Render("  >"+mark) + m.formatResult(r, selectedRowStyle))
+		} else {
+			sb.WriteString("   " + mark + m.formatResult(r, lipgloss.NewStyle()))
 		}
+		sb.WriteString("\n")
 	}
+	return sb.String()
+}
 
-	sb.WriteString("\n")
-	sb.WriteString(footerStyle.Render("  [Enter] open   [↑↓] navigate   [q] quit"))
+// status returns the tracker status for id, or Unseen when tracking is off.
+func (m SearchModel) status(id uint16) tracker.Status {
+	if m.tracker == nil {
+		return tracker.Unseen
+	}
+	return m.tracker.Status(id)
+}
+
+// renderProgress renders the caught progress bar plus per-generation and
+// per-regional-dex caught counts for the active profile.
+func (m SearchModel) renderProgress() string {
+	var all tracker.Progress
+	gens := m.tracker.ByGeneration(m.pokemon)
+	for _, g := range gens[1:] {
+		all.Seen += g.Seen
+		all.Caught += g.Caught
+		all.Total += g.Total
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  [%s] Caught %d/%d %s  Seen %d\n",
+		m.tracker.Active(), all.Caught, all.Total, ProgressBar(all.Caught, all.Total, 20), all.Seen))
+
+	var counts []string
+	for gen := 1; gen <= 3; gen++ {
+		counts = append(counts, fmt.Sprintf("Gen %d %d/%d", gen, gens[gen].Caught, gens[gen].Total))
+	}
+	for _, d := range []data.Pokedex{data.DexKanto, data.DexJohto, data.DexHoenn} {
+		pr := m.tracker.ByDex(m.pokemon, d)
+		counts = append(counts, fmt.Sprintf("%s %d/%d", d, pr.Caught, pr.Total))
+	}
+	sb.WriteString(dimStyle.Render("  "+strings.Join(counts, "  ")) + "\n")
 	return sb.String()
 }
 
-func formatSearchResult(p *data.Pokemon) string {
-	name := strings.ToUpper(p.Name[:1]) + p.Name[1:]
+// statusMarkers are the row markers for Unseen, Seen and Caught.
+var statusMarkers = [3]string{" ", "○", "●"}
+
+// formatResult renders a Pokémon row as before and other kinds with their kind
+// in place of the dex number. Names are shown in m.lang, with the
+// matched characters highlighted; base styles the rest of the row.
+func (m SearchModel) formatResult(r search.Result, base lipgloss.Style) string {
+	name, positions := resultName(r, m.lang)
+	switch r.Kind {
+	case search.KindMove:
+		return base.Render(fmt.Sprintf("  %-4s ", "Move")) + highlight(name, positions, 12, base) +
+			base.Render(" ["+r.Move.Type.String()+"]")
+	case search.KindAbility:
+		return base.Render(fmt.Sprintf("  %-4s ", "Abil")) + highlight(name, positions, 0, base)
+	case search.KindLocation:
+		return base.Render(fmt.Sprintf("  %-4s ", "Loc")) + highlight(name, positions, 0, base)
+	}
+	return formatSearchResult(r.Pokemon, m.status(r.Pokemon.ID), name, positions, base)
+}
+
+// resultName returns r's name in lang and the positions to highlight in it,
+// which are only known when the query matched that same name.
+func resultName(r search.Result, lang data.Language) (string, []int) {
+	if n := localName(r.Names(), lang); n != "" {
+		if r.Lang == lang {
+			return n, r.Positions
+		}
+		return n, nil
+	}
+	name := r.Name()
+	if r.Kind == search.KindPokemon {
+		name = pokemonName(r.Pokemon, lang)
+	}
+	if r.Lang == data.LangEnglish {
+		return name, r.Positions
+	}
+	return name, nil
+}
+
+func formatSearchResult(p *data.Pokemon, status tracker.Status, name string, positions []int, base lipgloss.Style) string {
 	types := ""
 	t1 := p.Types[0]
 	t2 := p.Types[1]
@@ -150,7 +379,46 @@ func formatSearchResult(p *data.Pokemon) string {
 	if t2 != data.TypeNone {
 		types += "[" + t2.String() + "]"
 	}
-	return fmt.Sprintf("#%03d %-12s %s", p.ID, name, types)
+	return base.Render(fmt.Sprintf("%s #%03d ", statusMarkers[status], p.ID)) +
+		highlight(name, positions, 12, base) + base.Render(" "+types)
+}
+
+// highlight renders s padded to width cells, with the runes at positions in
+// matchStyle and the rest in base. Each run is rendered separately so a
+// highlight doesn't reset base for the rest of the row.
+func highlight(s string, positions []int, width int, base lipgloss.Style) string {
+	matched := make(map[int]bool, len(positions))
+	for _, i := range positions {
+		matched[i] = true
+	}
+	hl := matchStyle.Inherit(base)
+	var sb strings.Builder
+	var run []rune
+	runMatched := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runMatched {
+			sb.WriteString(hl.Render(string(run)))
+		} else {
+			sb.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	runes := []rune(s)
+	for i, c := range runes {
+		if matched[i] != runMatched {
+			flush()
+			runMatched = matched[i]
+		}
+		run = append(run, c)
+	}
+	flush()
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		sb.WriteString(base.Render(strings.Repeat(" ", pad)))
+	}
+	return sb.String()
 }
 
 func max(a, b int) int {
@@ -1,10 +1,18 @@
 package tui
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/davidlawson7/pokedex/internal/config"
 	"github.com/davidlawson7/pokedex/internal/data"
+	"github.com/davidlawson7/pokedex/internal/search"
+	"github.com/davidlawson7/pokedex/internal/tracker"
+	"github.com/muesli/termenv"
 )
 
 // testPokemon is a small set of fixture Pokemon for TUI tests.
@@ -16,16 +24,25 @@ var testPokemon = []*data.Pokemon{
 	{ID: 1, Name: "bulbasaur", Types: [2]data.PokeType{data.TypeGrass, data.TypePoison}},
 }
 
+// pokemonResults wraps Pokémon as search results, as an empty query returns them.
+func pokemonResults(ps []*data.Pokemon) []search.Result {
+	out := make([]search.Result, len(ps))
+	for i, p := range ps {
+		out[i] = search.Result{Kind: search.KindPokemon, Pokemon: p}
+	}
+	return out
+}
+
 func newTestSearchModel() SearchModel {
 	m := NewSearchModel()
-	m.results = testPokemon
+	m.results = pokemonResults(testPokemon)
 	return m
 }
 
 func TestSearchModel_StartsWithAllResults(t *testing.T) {
 	m := NewSearchModel()
 	m.pokemon = testPokemon
-	m.results = testPokemon
+	m.results = pokemonResults(testPokemon)
 	if len(m.results) != len(testPokemon) {
 		t.Errorf("expected %d results, got %d", len(testPokemon), len(m.results))
 	}
@@ -44,7 +61,7 @@ func TestSearchModel_TypeUpdatesFilter(t *testing.T) {
 	// All results should match 'c' in some way
 	for _, p := range sm2.results {
 		found := false
-		for _, c := range p.Name {
+		for _, c := range p.Name() {
 			_ = c
 			found = true
 			break
@@ -111,12 +128,222 @@ func TestSearchModel_EnterEmitsSwitchMsg(t *testing.T) {
 
 func TestSearchModel_QuitEmitsQuit(t *testing.T) {
 	m := newTestSearchModel()
-	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
 	if cmd == nil {
-		t.Fatal("expected a command on 'q', got nil")
+		t.Fatal("expected a command on ctrl+c, got nil")
 	}
 	msg := cmd()
 	if msg != tea.Quit() {
 		t.Errorf("expected tea.Quit, got %T", msg)
 	}
+
+	// q is bound to quit, but typed characters go to the query.
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if got := next.(SearchModel).input.Value(); got != "q" {
+		t.Errorf("query = %q after typing q, want \"q\"", got)
+	}
+}
+
+func TestSearchModel_CtrlXCyclesTrackerStatus(t *testing.T) {
+	m := newTestSearchModel()
+	m.pokemon = testPokemon
+	m.tracker = tracker.New(filepath.Join(t.TempDir(), tracker.FileName))
+
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	sm2 := m2.(SearchModel)
+	if got := sm2.tracker.Status(testPokemon[0].ID); got != tracker.Seen {
+		t.Errorf("status after ctrl+x = %v, want Seen", got)
+	}
+	if sm2.err != nil {
+		t.Errorf("unexpected save error: %v", sm2.err)
+	}
+	view := sm2.View()
+	if !strings.Contains(view, "○ #004") {
+		t.Errorf("expected seen marker on charmander row, got: %q", view)
+	}
+	if !strings.Contains(view, "Caught 0/5") {
+		t.Errorf("expected progress line in view, got: %q", view)
+	}
+}
+
+func TestSearchModel_QueryFiltersAndReportsErrors(t *testing.T) {
+	m := newTestSearchModel()
+	m.pokemon = testPokemon
+	m.input.SetValue("type:fir")
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	sm2 := m2.(SearchModel)
+	if sm2.queryErr != nil || len(sm2.results) != 3 {
+		t.Fatalf("type:fire = %d results, err %v; want 3", len(sm2.results), sm2.queryErr)
+	}
+
+	m3, _ := sm2.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" atk>x")})
+	sm3 := m3.(SearchModel)
+	if sm3.queryErr == nil {
+		t.Fatal("expected a parse error for atk>x")
+	}
+	if len(sm3.results) != 3 {
+		t.Errorf("results = %d after parse error, want the last 3 kept", len(sm3.results))
+	}
+	if !strings.Contains(sm3.View(), "atk>x") {
+		t.Error("expected the parse error under the input")
+	}
+}
+
+func TestHighlight_MarksMatchedRunes(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	got := highlight("Pikachu", []int{0, 2, 4}, 10, lipgloss.NewStyle())
+	for _, c := range []string{"P", "k", "c"} {
+		if !strings.Contains(got, matchStyle.Render(c)) {
+			t.Errorf("%q: expected %q highlighted", got, c)
+		}
+	}
+	if plain := ansi.Strip(got); plain != "Pikachu   " {
+		t.Errorf("plain text = %q, want the name padded to 10", plain)
+	}
+}
+
+func TestSearchModel_LocalizedNames(t *testing.T) {
+	charizard := *testPokemon[2]
+	charizard.Names = data.LocalNames{data.LangJapanese: "リザードン", data.LangFrench: "Dracaufeu"}
+	m := newTestSearchModel()
+	m.lang = data.LangFrench
+	m.pokemon = []*data.Pokemon{&charizard, testPokemon[4]}
+
+	m.input.SetValue("dracau")
+	m2, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	sm := m2.(SearchModel)
+	if len(sm.results) != 1 {
+		t.Fatalf("results = %d, want Charizard by its French name", len(sm.results))
+	}
+	if name, positions := resultName(sm.results[0], sm.lang); name != "Dracaufeu" || len(positions) != 7 {
+		t.Errorf("resultName = %q %v, want Dracaufeu with 7 matched runes", name, positions)
+	}
+	// Matched in Japanese but shown in French: nothing to highlight.
+	sm.input.SetValue("リザード")
+	m3, _ := sm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'ン'}})
+	sm = m3.(SearchModel)
+	if len(sm.results) != 1 {
+		t.Fatalf("results = %d, want Charizard by its Japanese name", len(sm.results))
+	}
+	if name, positions := resultName(sm.results[0], sm.lang); name != "Dracaufeu" || positions != nil {
+		t.Errorf("resultName = %q %v, want Dracaufeu without highlights", name, positions)
+	}
+	// Bulbasaur has no French name and falls back to the dex name.
+	if got := pokemonName(testPokemon[4], sm.lang); got != "Bulbasaur" {
+		t.Errorf("pokemonName = %q, want Bulbasaur", got)
+	}
+}
+
+func TestAppModel_CtrlLCyclesAndSavesLanguage(t *testing.T) {
+	app := NewAppModel()
+	app.settingsPath = filepath.Join(t.TempDir(), config.SettingsFileName)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if cmd == nil {
+		t.Fatal("ctrl+l returned no command")
+	}
+	msg := cmd()
+	if lm, ok := msg.(setLanguageMsg); !ok || lm.lang != data.LangJapanese {
+		t.Fatalf("ctrl+l = %#v, want setLanguageMsg for Japanese", msg)
+	}
+	next, _ := app.Update(msg)
+	if a := next.(AppModel); a.lang != data.LangJapanese || a.search.lang != data.LangJapanese {
+		t.Errorf("language = %v, search %v, want Japanese", a.lang, a.search.lang)
+	}
+	s, err := config.LoadSettings(app.settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Language != "ja" {
+		t.Errorf("saved language = %q, want \"ja\"", s.Language)
+	}
+}
+
+func TestAppModel_CtrlSCyclesSortAndSaves(t *testing.T) {
+	app := NewAppModel()
+	app.settingsPath = filepath.Join(t.TempDir(), config.SettingsFileName)
+	app.search.pokemon = testPokemon
+	app.search.refresh()
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("ctrl+s returned no command")
+	}
+	msg := cmd()
+	if sm, ok := msg.(setSortMsg); !ok || sm.mode != search.SortDex {
+		t.Fatalf("ctrl+s = %#v, want setSortMsg for dex order", msg)
+	}
+	m, _ := app.Update(msg)
+	app = m.(AppModel)
+	if first := app.search.results[0].Pokemon; first.ID != 1 {
+		t.Errorf("first result = #%d, want #001 in dex order", first.ID)
+	}
+	if !strings.Contains(app.search.View(), "Sort: Dex no.") {
+		t.Error("expected the sort mode in the header")
+	}
+	s, err := config.LoadSettings(app.settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Sort != "dex" {
+		t.Errorf("saved sort = %q, want \"dex\"", s.Sort)
+	}
+
+	// Sorting applies on top of the filter.
+	app.search.sort = search.SortName
+	app.search.input.SetValue("char")
+	app.search.refresh()
+	var got []string
+	for _, r := range app.search.results {
+		got = append(got, r.Pokemon.Name)
+	}
+	if want := "charizard charmander charmeleon"; strings.Join(got, " ") != want {
+		t.Errorf("\"char\" by name = %v, want %s", got, want)
+	}
+}
+
+func TestSearchModel_FilterPanel(t *testing.T) {
+	m := newTestSearchModel()
+	m.pokemon = testPokemon
+	press := func(keys ...tea.KeyMsg) {
+		t.Helper()
+		for _, k := range keys {
+			next, _ := m.Update(k)
+			m = next.(SearchModel)
+		}
+	}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if !m.panel.open || !strings.Contains(m.View(), "[ ] Normal") {
+		t.Fatal("ctrl+f should open the panel")
+	}
+	// Normal, Fire, Water, Grass: three down to Grass, then toggle it.
+	press(down, down, down, tea.KeyMsg{Type: tea.KeySpace})
+	if got := len(m.results); got != 2 {
+		t.Errorf("Grass = %d results, want chikorita and bulbasaur", got)
+	}
+	if !strings.Contains(m.View(), "[x] Grass") {
+		t.Error("expected Grass checked in the panel")
+	}
+
+	// Closed, the chip stays and typing combines with the filter.
+	press(tea.KeyMsg{Type: tea.KeyEsc}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if m.panel.open {
+		t.Fatal("esc should close the panel")
+	}
+	if len(m.results) != 1 || m.results[0].Pokemon.Name != "bulbasaur" {
+		t.Errorf("Grass + \"b\" = %v, want bulbasaur", m.results)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, " Grass ") || strings.Contains(view, "[x] Grass") {
+		t.Errorf("expected a Grass chip without the panel, got: %q", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlF}, tea.KeyMsg{Type: tea.KeyBackspace})
+	if !m.panel.facets.Empty() {
+		t.Error("backspace should clear the filters")
+	}
 }
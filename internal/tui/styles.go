@@ -1,56 +1,40 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The styles and type colors are set by applyTheme.
 var (
 	// Layout
-	borderStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240"))
+	borderStyle      lipgloss.Style
+	headerStyle      lipgloss.Style
+	selectedRowStyle lipgloss.Style
+	dimStyle         lipgloss.Style
+	footerStyle      lipgloss.Style
+	errorStyle       lipgloss.Style
 
-	headerStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("255"))
+	// matchStyle marks the characters of a search result that matched the query.
+	matchStyle lipgloss.Style
 
-	selectedRowStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("255"))
+	// chipStyle marks an active filter above the search results.
+	chipStyle lipgloss.Style
 
-	dimStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240"))
-
-	footerStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241"))
+	// Type chart cells
+	superEffectiveStyle   lipgloss.Style
+	notVeryEffectiveStyle lipgloss.Style
 
 	// Tab styles
-	activeTabStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("212")).
-		Underline(true)
+	activeTabStyle   lipgloss.Style
+	inactiveTabStyle lipgloss.Style
 
-	inactiveTabStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240"))
+	// badgeStyle is a type badge before its type's background color.
+	badgeStyle lipgloss.Style
 
-	// Type badge colors (approximating game palette)
-	typeBadgeColors = map[string]lipgloss.Color{
-		"Normal":   lipgloss.Color("250"),
-		"Fire":     lipgloss.Color("202"),
-		"Water":    lipgloss.Color("33"),
-		"Grass":    lipgloss.Color("70"),
-		"Electric": lipgloss.Color("220"),
-		"Ice":      lipgloss.Color("153"),
-		"Fighting": lipgloss.Color("124"),
-		"Poison":   lipgloss.Color("129"),
-		"Ground":   lipgloss.Color("178"),
-		"Flying":   lipgloss.Color("105"),
-		"Psychic":  lipgloss.Color("205"),
-		"Bug":      lipgloss.Color("106"),
-		"Rock":     lipgloss.Color("143"),
-		"Ghost":    lipgloss.Color("60"),
-		"Dragon":   lipgloss.Color("62"),
-		"Dark":     lipgloss.Color("95"),
-		"Steel":    lipgloss.Color("103"),
-	}
+	// Type badge colors, by type name
+	typeBadgeColors map[string]lipgloss.TerminalColor
 )
 
 // TypeBadge renders a colored type badge string.
@@ -60,12 +44,10 @@ func TypeBadge(typeName string) string {
 	}
 	color, ok := typeBadgeColors[typeName]
 	if !ok {
-		color = lipgloss.Color("250")
+		color = typeBadgeColors["Normal"]
 	}
-	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("0")).
+	return badgeStyle.
 		Background(color).
-		Padding(0, 1).
 		Render(typeName)
 }
 
@@ -86,3 +68,15 @@ func StatBar(value uint8) string {
 	}
 	return bar
 }
+
+// ProgressBar renders a bar of width cells filled in proportion to n/total.
+func ProgressBar(n, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = n * width / total
+	}
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/davidlawson7/pokedex/internal/config"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// Theme is the colors of the UI by role, as named in the palettes: parts of
+// the screen like "header" or "selected", and each type's lower-case name
+// for its badge.
+type Theme struct {
+	colors map[string]lipgloss.TerminalColor
+	// reverse marks the selected row, chips and type badges with reverse
+	// video rather than a background color, for themes without colors.
+	reverse bool
+}
+
+// c is a 256-color code with the 16-color one to use in its place on
+// terminals that have only those.
+func c(ansi256, ansi string) lipgloss.CompleteColor {
+	return lipgloss.CompleteColor{TrueColor: ansi256, ANSI256: ansi256, ANSI: ansi}
+}
+
+// darkPalette is for light text on a dark background.
+var darkPalette = map[string]lipgloss.CompleteColor{
+	"border": c("240", "8"), "header": c("255", "15"), "selected": c("255", "15"), "selected-text": c("0", "0"),
+	"dim": c("240", "8"), "footer": c("241", "8"), "error": c("203", "9"), "match": c("214", "11"),
+	"chip": c("110", "6"), "chip-text": c("0", "0"), "super-effective": c("78", "10"),
+	"not-very-effective": c("203", "9"), "active-tab": c("212", "13"), "inactive-tab": c("240", "8"),
+	"badge-text": c("0", "0"),
+
+	// Approximating the games' palette
+	"normal": c("250", "7"), "fire": c("202", "9"), "water": c("33", "12"), "grass": c("70", "2"),
+	"electric": c("220", "11"), "ice": c("153", "14"), "fighting": c("124", "1"), "poison": c("129", "5"),
+	"ground": c("178", "3"), "flying": c("105", "12"), "psychic": c("205", "13"), "bug": c("106", "2"),
+	"rock": c("143", "3"), "ghost": c("60", "5"), "dragon": c("62", "4"), "dark": c("95", "8"),
+	"steel": c("103", "7"),
+}
+
+// lightPalette is for dark text on a light background; the type colors are
+// darker so they read as text and carry white badge text.
+var lightPalette = map[string]lipgloss.CompleteColor{
+	"border": c("250", "7"), "header": c("232", "0"), "selected": c("236", "0"), "selected-text": c("255", "15"),
+	"dim": c("245", "8"), "footer": c("244", "8"), "error": c("160", "1"), "match": c("166", "3"),
+	"chip": c("31", "4"), "chip-text": c("255", "15"), "super-effective": c("28", "2"),
+	"not-very-effective": c("160", "1"), "active-tab": c("162", "5"), "inactive-tab": c("245", "8"),
+	"badge-text": c("255", "15"),
+
+	"normal": c("244", "8"), "fire": c("166", "1"), "water": c("25", "4"), "grass": c("28", "2"),
+	"electric": c("136", "3"), "ice": c("31", "6"), "fighting": c("88", "1"), "poison": c("91", "5"),
+	"ground": c("130", "3"), "flying": c("61", "4"), "psychic": c("162", "5"), "bug": c("64", "2"),
+	"rock": c("101", "3"), "ghost": c("54", "5"), "dragon": c("55", "4"), "dark": c("52", "0"),
+	"steel": c("66", "6"),
+}
+
+// highContrastPalette uses only the 16 basic colors, at full brightness
+// where it can, on a dark background.
+var highContrastPalette = map[string]lipgloss.CompleteColor{
+	"border": c("15", "15"), "header": c("15", "15"), "selected": c("11", "11"), "selected-text": c("0", "0"),
+	"dim": c("7", "7"), "footer": c("7", "7"), "error": c("9", "9"), "match": c("11", "11"),
+	"chip": c("14", "14"), "chip-text": c("0", "0"), "super-effective": c("10", "10"),
+	"not-very-effective": c("9", "9"), "active-tab": c("14", "14"), "inactive-tab": c("7", "7"),
+	"badge-text": c("0", "0"),
+
+	"normal": c("15", "15"), "fire": c("9", "9"), "water": c("12", "12"), "grass": c("10", "10"),
+	"electric": c("11", "11"), "ice": c("14", "14"), "fighting": c("1", "1"), "poison": c("13", "13"),
+	"ground": c("3", "3"), "flying": c("6", "6"), "psychic": c("5", "5"), "bug": c("2", "2"),
+	"rock": c("3", "3"), "ghost": c("5", "5"), "dragon": c("4", "4"), "dark": c("8", "8"),
+	"steel": c("7", "7"),
+}
+
+// themes are the themes a theme file can name; "" is the default, which
+// picks the dark or light palette by the terminal's background.
+var themes = map[string]func() Theme{
+	"": func() Theme {
+		t := Theme{colors: make(map[string]lipgloss.TerminalColor)}
+		for role, dark := range darkPalette {
+			t.colors[role] = lipgloss.CompleteAdaptiveColor{Light: lightPalette[role], Dark: dark}
+		}
+		return t
+	},
+	"dark":          func() Theme { return paletteTheme(darkPalette) },
+	"light":         func() Theme { return paletteTheme(lightPalette) },
+	"high-contrast": func() Theme { return paletteTheme(highContrastPalette) },
+	"monochrome": func() Theme {
+		t := Theme{colors: make(map[string]lipgloss.TerminalColor), reverse: true}
+		for role := range darkPalette {
+			t.colors[role] = lipgloss.NoColor{}
+		}
+		return t
+	},
+}
+
+// paletteTheme is a theme with a palette's colors whatever the background.
+func paletteTheme(p map[string]lipgloss.CompleteColor) Theme {
+	t := Theme{colors: make(map[string]lipgloss.TerminalColor)}
+	for role, color := range p {
+		t.colors[role] = color
+	}
+	return t
+}
+
+var colorValue = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|[0-9]{1,3})$`)
+
+// NewTheme builds the theme a theme file describes: the named theme, then
+// its own colors, which must be for known roles.
+func NewTheme(cfg config.Theme) (Theme, error) {
+	newTheme, ok := themes[cfg.Name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (want dark, light, high-contrast or monochrome)", cfg.Name)
+	}
+	t := newTheme()
+	for role, value := range cfg.Colors {
+		if _, ok := t.colors[role]; !ok {
+			return Theme{}, fmt.Errorf("unknown color role %q", role)
+		}
+		if n, err := strconv.Atoi(value); !colorValue.MatchString(value) || (err == nil && n > 255) {
+			return Theme{}, fmt.Errorf("%s: color %q isn't #rrggbb or 0-255", role, value)
+		}
+		t.colors[role] = lipgloss.Color(value)
+	}
+	return t, nil
+}
+
+// noColor returns the monochrome theme when the NO_COLOR environment
+// variable is set, whatever the theme file says, and cfg otherwise.
+func noColor(cfg config.Theme, set bool) config.Theme {
+	if set {
+		return config.Theme{Name: "monochrome"}
+	}
+	return cfg
+}
+
+func init() {
+	applyTheme(themes[""]())
+}
+
+// applyTheme rebuilds the styles and type colors from t.
+func applyTheme(t Theme) {
+	col := func(role string) lipgloss.TerminalColor { return t.colors[role] }
+	// highlight marks text with a background color, or reverse video.
+	highlight := func(s lipgloss.Style, bg, fg string) lipgloss.Style {
+		if t.reverse {
+			return s.Reverse(true)
+		}
+		return s.Foreground(col(fg)).Background(col(bg))
+	}
+
+	borderStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(col("border"))
+
+	headerStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(col("header"))
+
+	selectedRowStyle = highlight(lipgloss.NewStyle(), "selected", "selected-text")
+
+	dimStyle = lipgloss.NewStyle().
+		Foreground(col("dim")).
+		Faint(t.reverse)
+
+	footerStyle = lipgloss.NewStyle().
+		Foreground(col("footer")).
+		Faint(t.reverse)
+
+	errorStyle = lipgloss.NewStyle().
+		Foreground(col("error"))
+
+	matchStyle = lipgloss.NewStyle().
+		Bold(true).
+		Underline(t.reverse).
+		Foreground(col("match"))
+
+	chipStyle = highlight(lipgloss.NewStyle(), "chip", "chip-text").
+		Padding(0, 1)
+
+	superEffectiveStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(col("super-effective"))
+
+	notVeryEffectiveStyle = lipgloss.NewStyle().
+		Foreground(col("not-very-effective"))
+
+	activeTabStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(col("active-tab")).
+		Underline(true)
+
+	inactiveTabStyle = lipgloss.NewStyle().
+		Foreground(col("inactive-tab")).
+		Faint(t.reverse)
+
+	badgeStyle = lipgloss.NewStyle().
+		Foreground(col("badge-text")).
+		Reverse(t.reverse).
+		Padding(0, 1)
+
+	typeBadgeColors = make(map[string]lipgloss.TerminalColor)
+	for typ := data.TypeNormal; typ <= data.TypeSteel; typ++ {
+		typeBadgeColors[typ.String()] = col(strings.ToLower(typ.String()))
+	}
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/davidlawson7/pokedex/internal/config"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// useTheme applies a theme for the rest of the test.
+func useTheme(t *testing.T, th Theme) {
+	t.Helper()
+	t.Cleanup(func() { applyTheme(themes[""]()) })
+	applyTheme(th)
+}
+
+func TestThemes_CoverEveryRoleAndType(t *testing.T) {
+	roles := slices.Sorted(maps.Keys(darkPalette))
+	for typ := data.TypeNormal; typ <= data.TypeSteel; typ++ {
+		if _, ok := darkPalette[strings.ToLower(typ.String())]; !ok {
+			t.Errorf("dark palette has no color for %s", typ)
+		}
+	}
+	for name, p := range map[string]map[string]lipgloss.CompleteColor{"light": lightPalette, "high-contrast": highContrastPalette} {
+		if got := slices.Sorted(maps.Keys(p)); !slices.Equal(got, roles) {
+			t.Errorf("%s palette roles = %v, want %v", name, got, roles)
+		}
+	}
+	for name := range themes {
+		if _, err := NewTheme(config.Theme{Name: name}); err != nil {
+			t.Errorf("theme %q: %v", name, err)
+		}
+	}
+}
+
+func TestNewTheme_Colors(t *testing.T) {
+	th, err := NewTheme(config.Theme{Name: "light", Colors: map[string]string{"fire": "#ff4400", "header": "16"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.colors["fire"] != lipgloss.Color("#ff4400") || th.colors["header"] != lipgloss.Color("16") {
+		t.Errorf("overrides not applied: fire %v, header %v", th.colors["fire"], th.colors["header"])
+	}
+	if th.colors["water"] != lightPalette["water"] {
+		t.Errorf("water = %v, want the light palette's", th.colors["water"])
+	}
+
+	tests := []struct {
+		cfg  config.Theme
+		want string
+	}{
+		{config.Theme{Name: "solarized"}, `unknown theme "solarized"`},
+		{config.Theme{Colors: map[string]string{"sparkle": "1"}}, `unknown color role "sparkle"`},
+		{config.Theme{Colors: map[string]string{"fire": "orange"}}, `fire: color "orange"`},
+		{config.Theme{Colors: map[string]string{"fire": "300"}}, `fire: color "300"`},
+	}
+	for _, tt := range tests {
+		if _, err := NewTheme(tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewTheme(%+v) = %v, want an error containing %q", tt.cfg, err, tt.want)
+		}
+	}
+}
+
+func TestApplyTheme_Monochrome(t *testing.T) {
+	th, err := NewTheme(config.Theme{Name: "monochrome"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	useTheme(t, th)
+	if !selectedRowStyle.GetReverse() || !chipStyle.GetReverse() || !badgeStyle.GetReverse() {
+		t.Error("monochrome should mark selections, chips and badges with reverse video")
+	}
+	if _, ok := selectedRowStyle.GetBackground().(lipgloss.NoColor); !ok {
+		t.Errorf("selected row background = %v, want none", selectedRowStyle.GetBackground())
+	}
+	if typeBadgeColors["Fire"] != (lipgloss.NoColor{}) {
+		t.Errorf("Fire badge color = %v, want none", typeBadgeColors["Fire"])
+	}
+}
+
+func TestNoColor_OverridesTheThemeFile(t *testing.T) {
+	cfg := config.Theme{Name: "light", Colors: map[string]string{"fire": "#ff4400"}}
+	if got := noColor(cfg, true); got.Name != "monochrome" || got.Colors != nil {
+		t.Errorf("with NO_COLOR = %+v, want plain monochrome", got)
+	}
+	if got := noColor(cfg, false); got.Name != "light" || got.Colors["fire"] != "#ff4400" {
+		t.Errorf("without NO_COLOR = %+v, want the file's theme", got)
+	}
+}
+
+func TestApplyTheme_DefaultAdaptsToBackground(t *testing.T) {
+	th, err := NewTheme(config.Theme{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	useTheme(t, th)
+	want := lipgloss.CompleteAdaptiveColor{Light: lightPalette["fire"], Dark: darkPalette["fire"]}
+	if typeBadgeColors["Fire"] != want {
+		t.Errorf("Fire badge color = %v, want %v", typeBadgeColors["Fire"], want)
+	}
+	if selectedRowStyle.GetReverse() {
+		t.Error("the default theme shouldn't use reverse video")
+	}
+}
@@ -0,0 +1,253 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// TypeChartModel shows the attacker × defender type chart of a generation,
+// explains the cell under the cursor, and lists every attacking type's
+// multiplier against a defender with the one or two types picked from the
+// chart's columns.
+type TypeChartModel struct {
+	gen      data.Generation
+	row, col int              // cursor, as indices into data.TypesInGen
+	defender [2]data.PokeType // picked with space; TypeNone when unset
+	width    int
+	height   int
+}
+
+// NewTypeChartModel creates a Gen 3 type chart with the cursor on Normal
+// attacking Normal.
+func NewTypeChartModel(width, height int) TypeChartModel {
+	return TypeChartModel{gen: 3, width: width, height: height}
+}
+
+func (m TypeChartModel) Init() tea.Cmd { return nil }
+
+// typeChartBindings lists the bindings the type chart matches.
+func typeChartBindings(k *KeyMap) []*key.Binding {
+	bs := []*key.Binding{&k.Up, &k.Down, &k.Left, &k.Right, &k.Escape, &k.Tab, &k.Quit, &k.Toggle, &k.Clear}
+	for i := range k.GenKeys {
+		bs = append(bs, &k.GenKeys[i])
+	}
+	return bs
+}
+
+func (m TypeChartModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		n := len(data.TypesInGen(m.gen))
+		switch {
+		case key.Matches(msg, keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, keys.Escape):
+			return m, func() tea.Msg { return backMsg{} }
+
+		case key.Matches(msg, keys.Tab):
+			return m, func() tea.Msg { return switchToSearchMsg{} }
+
+		case key.Matches(msg, keys.Up):
+			m.row = (m.row + n - 1) % n
+		case key.Matches(msg, keys.Down):
+			m.row = (m.row + 1) % n
+		case key.Matches(msg, keys.Left):
+			m.col = (m.col + n - 1) % n
+		case key.Matches(msg, keys.Right):
+			m.col = (m.col + 1) % n
+
+		case key.Matches(msg, keys.Toggle):
+			m.pick(data.TypesInGen(m.gen)[m.col])
+
+		case key.Matches(msg, keys.Clear):
+			m.defender = [2]data.PokeType{}
+
+		default:
+			if n := numberKey(msg, keys.GenKeys[:]); n != 0 {
+				m.setGen(data.Generation(n))
+			}
+		}
+	}
+	return m, nil
+}
+
+// pick adds t to the defending types, replacing the older of two, or
+// removes it if it's already there.
+func (m *TypeChartModel) pick(t data.PokeType) {
+	switch t {
+	case m.defender[0]:
+		m.defender = [2]data.PokeType{m.defender[1], data.TypeNone}
+	case m.defender[1]:
+		m.defender[1] = data.TypeNone
+	default:
+		if m.defender[0] == data.TypeNone {
+			m.defender[0] = t
+		} else if m.defender[1] == data.TypeNone {
+			m.defender[1] = t
+		} else {
+			m.defender = [2]data.PokeType{m.defender[1], t}
+		}
+	}
+}
+
+// setGen switches the chart's generation, dropping picks and moving the
+// cursor off types the generation doesn't have.
+func (m *TypeChartModel) setGen(gen data.Generation) {
+	m.gen = gen
+	n := len(data.TypesInGen(gen))
+	m.row, m.col = min(m.row, n-1), min(m.col, n-1)
+	for _, t := range m.defender {
+		if t != data.TypeNone && int(t) > n {
+			m.pick(t)
+		}
+	}
+}
+
+func (m TypeChartModel) View() string {
+	types := data.TypesInGen(m.gen)
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("  Type chart  Gen %d  (rows attack, columns defend)\n", m.gen))
+	sb.WriteString(strings.Repeat("─", max(m.width-2, 40)) + "\n")
+
+	sb.WriteString(strings.Repeat(" ", 11))
+	for _, d := range types {
+		style := typeStyle(d)
+		if d == m.defender[0] || d == m.defender[1] {
+			style = style.Underline(true)
+		}
+		sb.WriteString(" " + style.Render(d.String()[:3]))
+	}
+	sb.WriteString("\n")
+	for r, a := range types {
+		sb.WriteString("  " + typeStyle(a).Width(9).Render(a.String()))
+		for c, d := range types {
+			cell := effectivenessCell(data.TypeEffectiveness(a, d, m.gen))
+			if r == m.row && c == m.col {
+				cell = selectedRowStyle.Render(fmt.Sprintf(" %s ", cellText(data.TypeEffectiveness(a, d, m.gen))))
+			}
+			sb.WriteString(" " + cell)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n" + m.explain() + "\n\n")
+	sb.WriteString(m.renderDefender())
+
+	sb.WriteString("\n")
+	help := func(b key.Binding) string { return keyHelp(b, false) }
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("  %s:back  %s:gen  %s%s%s%s:move  %s:pick defender  %s:clear  %s:search",
+		help(keys.Escape), numberKeysHelp(keys.GenKeys[:]), help(keys.Left), help(keys.Up), help(keys.Down),
+		help(keys.Right), help(keys.Toggle), help(keys.Clear), help(keys.Tab))))
+	return sb.String()
+}
+
+// explain describes the cell under the cursor, and how it differed in the
+// other generations when it did.
+func (m TypeChartModel) explain() string {
+	types := data.TypesInGen(m.gen)
+	a, d := types[m.row], types[m.col]
+	e := data.TypeEffectiveness(a, d, m.gen)
+	s := fmt.Sprintf("  %s attacking %s: %s", a, d, e)
+	if desc := effectivenessDesc(e); desc != "" {
+		s += " " + desc
+	}
+	if m.gen == 1 {
+		if later := data.TypeEffectiveness(a, d, 2); later != e {
+			s += dimStyle.Render(fmt.Sprintf("  (%s from Gen 2)", later))
+		}
+	} else if a <= data.TypeDragon && d <= data.TypeDragon {
+		if gen1 := data.TypeEffectiveness(a, d, 1); gen1 != e {
+			s += dimStyle.Render(fmt.Sprintf("  (%s in Gen 1)", gen1))
+		}
+	}
+	return s
+}
+
+// renderDefender lists the attacking types grouped by their multiplier
+// against the picked defending types, leaving out the neutral ones.
+func (m TypeChartModel) renderDefender() string {
+	if m.defender[0] == data.TypeNone {
+		return dimStyle.Render("  Press space on a column to pick up to two defending types") + "\n"
+	}
+	var sb strings.Builder
+	names := []string{TypeBadge(m.defender[0].String())}
+	if m.defender[1] != data.TypeNone {
+		names = append(names, TypeBadge(m.defender[1].String()))
+	}
+	sb.WriteString(headerStyle.Render("  Defending as ") + strings.Join(names, " ") + "\n")
+
+	order := []data.Effectiveness{data.DoubleEffective, data.SuperEffective, data.NotVeryEffective,
+		data.QuarterEffective, data.NoEffect}
+	byEffect := make(map[data.Effectiveness][]string)
+	for _, a := range data.TypesInGen(m.gen) {
+		e := data.DualEffectiveness(a, m.defender, m.gen)
+		byEffect[e] = append(byEffect[e], typeStyle(a).Render(a.String()))
+	}
+	for _, e := range order {
+		if len(byEffect[e]) > 0 {
+			sb.WriteString(fmt.Sprintf("    %s  %s\n", effectivenessStyle(e).Render(e.String()), strings.Join(byEffect[e], " ")))
+		}
+	}
+	return sb.String()
+}
+
+// typeStyle colors text with t's badge color.
+func typeStyle(t data.PokeType) lipgloss.Style {
+	color, ok := typeBadgeColors[t.String()]
+	if !ok {
+		color = typeBadgeColors["Normal"]
+	}
+	return lipgloss.NewStyle().Foreground(color)
+}
+
+// cellText is a chart cell's text: the multiplier, or a dot for neutral.
+func cellText(e data.Effectiveness) string {
+	switch e {
+	case data.Neutral:
+		return "·"
+	case data.NoEffect:
+		return "0"
+	}
+	return strings.TrimSuffix(e.String(), "×")
+}
+
+// effectivenessCell renders a multiplier as a padded chart cell.
+func effectivenessCell(e data.Effectiveness) string {
+	return effectivenessStyle(e).Render(fmt.Sprintf(" %s ", cellText(e)))
+}
+
+// effectivenessStyle is green for multipliers that help the attacker, red
+// for those that don't and dim for neutral.
+func effectivenessStyle(e data.Effectiveness) lipgloss.Style {
+	switch {
+	case e > data.Neutral:
+		return superEffectiveStyle
+	case e == data.Neutral:
+		return dimStyle
+	}
+	return notVeryEffectiveStyle
+}
+
+// effectivenessDesc is the games' wording for a multiplier.
+func effectivenessDesc(e data.Effectiveness) string {
+	switch {
+	case e == data.NoEffect:
+		return "no effect"
+	case e > data.Neutral:
+		return "super effective"
+	case e < data.Neutral:
+		return "not very effective"
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+func TestTypeChartModel_View(t *testing.T) {
+	m := NewTypeChartModel(80, 24)
+	view := ansi.Strip(m.View())
+	for _, want := range []string{
+		"            Nor Fir Wat Gra",
+		"  Fire       ·   ½   ½   2 ",
+		"  Normal attacking Normal: 1×",
+		"Dra Dar Ste",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in type chart:\n%s", want, view)
+		}
+	}
+
+	// Ghost attacking Psychic in Gen 1 was the games' bug.
+	m.row, m.col = int(data.TypeGhost-1), int(data.TypePsychic-1)
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	m = next.(TypeChartModel)
+	view = ansi.Strip(m.View())
+	if !strings.Contains(view, "Ghost attacking Psychic: 0× no effect  (2× from Gen 2)") {
+		t.Errorf("expected the Gen 1 Ghost note:\n%s", view)
+	}
+	if strings.Contains(view, "Ste") {
+		t.Errorf("Gen 1 has no Steel:\n%s", view)
+	}
+}
+
+func TestTypeChartModel_DualDefender(t *testing.T) {
+	m := NewTypeChartModel(80, 24)
+	pick := func(t data.PokeType) {
+		m.col = int(t - 1)
+		next, _ := m.Update(tea.KeyMsg{Type: tea.KeySpace})
+		m = next.(TypeChartModel)
+	}
+	pick(data.TypeFire)
+	pick(data.TypeFlying)
+	if m.defender != [2]data.PokeType{data.TypeFire, data.TypeFlying} {
+		t.Fatalf("defender = %v", m.defender)
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{
+		"Defending as  Fire   Flying",
+		"    4×  Rock",
+		"    2×  Water Electric",
+		"    0×  Ground",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in type chart:\n%s", want, view)
+		}
+	}
+
+	// A third pick replaces the older one; picking again removes it.
+	pick(data.TypeSteel)
+	if m.defender != [2]data.PokeType{data.TypeFlying, data.TypeSteel} {
+		t.Errorf("after a third pick defender = %v", m.defender)
+	}
+	pick(data.TypeFlying)
+	if m.defender != [2]data.PokeType{data.TypeSteel, data.TypeNone} {
+		t.Errorf("after unpicking defender = %v", m.defender)
+	}
+
+	// Gen 1 has no Steel, so the pick goes.
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if m = next.(TypeChartModel); m.defender != [2]data.PokeType{} {
+		t.Errorf("Gen 1 defender = %v, want none", m.defender)
+	}
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/davidlawson7/pokedex/internal/data"
+)
+
+// versionPicker is an overlay listing every game version grouped by
+// generation, for choosing the one a screen shows.
+type versionPicker struct {
+	open   bool
+	cursor data.GameVersion
+}
+
+// show opens the picker with the cursor on the current version.
+func (p *versionPicker) show(current data.GameVersion) {
+	p.open = true
+	p.cursor = current
+}
+
+// versionPickerBindings lists the bindings the version picker matches.
+func versionPickerBindings(k *KeyMap) []*key.Binding {
+	return []*key.Binding{&k.Up, &k.Down, &k.Enter, &k.Escape}
+}
+
+// update handles a key while the picker is open. It returns the version
+// picked with enter, or 0 if none was; enter and esc close the picker.
+func (p *versionPicker) update(msg tea.KeyMsg) data.GameVersion {
+	switch {
+	case key.Matches(msg, keys.Escape):
+		p.open = false
+	case key.Matches(msg, keys.Up):
+		if p.cursor > data.GameRed {
+			p.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if p.cursor < data.GameLeafGreen {
+			p.cursor++
+		}
+	case key.Matches(msg, keys.Enter):
+		p.open = false
+		return p.cursor
+	}
+	return 0
+}
+
+// view renders the picker, noting which versions have moves or wild
+// encounters for the Pokémon and marking the current one.
+func (p versionPicker) view(mon *data.Pokemon, current data.GameVersion) string {
+	var sb strings.Builder
+	sb.WriteString(headerStyle.Render("Version") + "\n")
+	for v := data.GameRed; v <= data.GameLeafGreen; v++ {
+		if v == data.GameRed || data.GenForVersion(v) != data.GenForVersion(v-1) {
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("Generation %d", data.GenForVersion(v))) + "\n")
+		}
+		mark := " "
+		if v == current {
+			mark = "•"
+		}
+		row := fmt.Sprintf("%s %-10s %s", mark, v, versionData(mon, v))
+		if v == p.cursor {
+			sb.WriteString(selectedRowStyle.Render("> "+row) + "\n")
+		} else {
+			sb.WriteString("  " + row + "\n")
+		}
+	}
+	sb.WriteString(footerStyle.Render(fmt.Sprintf("%s%s:select  %s:choose  %s:cancel",
+		keyHelp(keys.Up, false), keyHelp(keys.Down, false), keyHelp(keys.Enter, false), keyHelp(keys.Escape, false))))
+	return borderStyle.Padding(0, 1).Render(sb.String())
+}
+
+// versionData says whether a version has learnset and wild encounter data
+// for the Pokémon.
+func versionData(mon *data.Pokemon, v data.GameVersion) string {
+	if mon == nil {
+		return ""
+	}
+	var have []string
+	for _, vls := range mon.Moves {
+		if vls.Version == v && len(vls.Moves) > 0 {
+			have = append(have, "moves")
+			break
+		}
+	}
+	for _, loc := range mon.Locations {
+		if loc.Game == v {
+			have = append(have, "wild")
+			break
+		}
+	}
+	if len(have) == 0 {
+		return "no data"
+	}
+	return strings.Join(have, ", ")
+}